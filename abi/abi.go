@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package abi defines the protocol between apex-api.wasm and its host.
+//
+// Both the apex.resolve function imported by the module and the parse
+// function it exports return a pointer and a size packed into a uint64
+// (see Pack). They point to a response whose first byte is a Status
+// followed by a payload. For StatusOK the payload is the result: the
+// source of the import for resolve and the namespace as JSON for
+// parse. For any other status the payload is a JSON array of errors in
+// the shape of errors.Errors. A packed value of zero means no response
+// could be produced at all, for example because memory could not be
+// allocated.
+package abi
+
+import (
+	"encoding/json"
+	stderrs "errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/apexlang/apex-go/errors"
+)
+
+// Status is the first byte of a response.
+type Status byte
+
+const (
+	// StatusOK means the payload is the result.
+	StatusOK Status = iota
+	// StatusInvalid means the spec has diagnostics, which are the
+	// payload. It is only returned by parse.
+	StatusInvalid
+	// StatusNotFound means the import does not exist. It is only
+	// returned by resolve.
+	StatusNotFound
+	// StatusError means the request failed for a reason unrelated to
+	// the spec, such as an I/O error of the host.
+	StatusError
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusInvalid:
+		return "invalid"
+	case StatusNotFound:
+		return "not found"
+	case StatusError:
+		return "error"
+	}
+	return fmt.Sprintf("status(%d)", byte(s))
+}
+
+// ErrNotFound can be wrapped by resolvers to report that an import
+// does not exist, which is returned with StatusNotFound.
+var ErrNotFound = stderrs.New("not found")
+
+// ErrNoResponse is returned for a packed pointer and size of zero.
+var ErrNoResponse = stderrs.New("no response")
+
+// Error is a response with a status other than StatusOK.
+type Error struct {
+	Status Status
+	Errors errors.Errors
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Message
+	}
+	if len(messages) == 0 {
+		return e.Status.String()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports ErrNotFound for StatusNotFound errors.
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.Status == StatusNotFound
+}
+
+// Pack packs a pointer and a size into a uint64.
+func Pack(ptr, size uint32) uint64 {
+	return uint64(ptr)<<32 | uint64(size)
+}
+
+// Unpack unpacks a pointer and a size packed by Pack.
+func Unpack(ptrSize uint64) (ptr, size uint32) {
+	return uint32(ptrSize >> 32), uint32(ptrSize)
+}
+
+// Encode returns a response with status and payload.
+func Encode(status Status, payload []byte) []byte {
+	response := make([]byte, 1+len(payload))
+	response[0] = byte(status)
+	copy(response[1:], payload)
+	return response
+}
+
+// EncodeErrors returns a response with status and errs as payload.
+func EncodeErrors(status Status, errs ...error) []byte {
+	payload, err := json.Marshal(errors.Convert(errs...))
+	if err != nil {
+		// Error messages always marshal, but keep the response valid.
+		payload = []byte(`[{"message":"could not encode errors"}]`)
+	}
+	return Encode(status, payload)
+}
+
+// Decode returns the payload of response if its status is StatusOK.
+// Otherwise it returns an *Error carrying the decoded errors.
+func Decode(response []byte) ([]byte, error) {
+	if len(response) == 0 {
+		return nil, stderrs.New("empty response")
+	}
+	status, payload := Status(response[0]), response[1:]
+	switch status {
+	case StatusOK:
+		return payload, nil
+	case StatusInvalid, StatusNotFound, StatusError:
+		var errs errors.Errors
+		if err := json.Unmarshal(payload, &errs); err != nil {
+			return nil, fmt.Errorf("invalid %s response: %w", status, err)
+		}
+		return nil, &Error{Status: status, Errors: errs}
+	}
+	return nil, fmt.Errorf("unknown response status %d", byte(status))
+}
+
+// EncodeResolve returns the response for the import resolved by a
+// resolver that returned source and err.
+func EncodeResolve(source string, err error) []byte {
+	if err == nil {
+		return Encode(StatusOK, []byte(source))
+	}
+	var abiErr *Error
+	if stderrs.As(err, &abiErr) {
+		return EncodeErrors(abiErr.Status, errorsOf(abiErr)...)
+	}
+	if isNotFound(err) {
+		return EncodeErrors(StatusNotFound, err)
+	}
+	return EncodeErrors(StatusError, err)
+}
+
+// DecodeResolve returns the source from a resolve response. read
+// returns the response at a pointer and size, or false if the range is
+// out of bounds.
+func DecodeResolve(ptrSize uint64, read func(ptr, size uint32) ([]byte, bool)) (string, error) {
+	if ptrSize == 0 {
+		return "", ErrNoResponse
+	}
+	response, ok := read(Unpack(ptrSize))
+	if !ok {
+		return "", stderrs.New("resolve response is out of range")
+	}
+	payload, err := Decode(response)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// isNotFound returns true if err reports an import that does not exist.
+func isNotFound(err error) bool {
+	return stderrs.Is(err, ErrNotFound) || stderrs.Is(err, fs.ErrNotExist)
+}
+
+func errorsOf(e *Error) []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package abi
+
+import (
+	stderrs "errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+)
+
+func TestPack(t *testing.T) {
+	ptr, size := Unpack(Pack(0xDEADBEEF, 0x01020304))
+	if ptr != 0xDEADBEEF || size != 0x01020304 {
+		t.Fatalf("got %x, %x", ptr, size)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		response []byte
+		payload  string
+		status   Status
+		err      string
+	}{
+		{
+			name:     "ok",
+			response: Encode(StatusOK, []byte("payload")),
+			payload:  "payload",
+		},
+		{
+			name:     "ok without payload",
+			response: Encode(StatusOK, nil),
+		},
+		{
+			name:     "invalid",
+			response: EncodeErrors(StatusInvalid, fmt.Errorf("first"), fmt.Errorf("second")),
+			status:   StatusInvalid,
+			err:      "first; second",
+		},
+		{
+			name:     "not found",
+			response: EncodeErrors(StatusNotFound, fmt.Errorf("missing")),
+			status:   StatusNotFound,
+			err:      "missing",
+		},
+		{
+			name:     "error",
+			response: EncodeErrors(StatusError, fmt.Errorf("failed")),
+			status:   StatusError,
+			err:      "failed",
+		},
+		{
+			name:     "error without errors",
+			response: Encode(StatusError, []byte("[]")),
+			status:   StatusError,
+			err:      "error",
+		},
+		{
+			name: "empty",
+			err:  "empty response",
+		},
+		{
+			name:     "unknown status",
+			response: Encode(Status(42), []byte("payload")),
+			err:      "unknown response status 42",
+		},
+		{
+			name:     "malformed errors",
+			response: Encode(StatusError, []byte("not json")),
+			err:      "invalid error response: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := Decode(tt.response)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if string(payload) != tt.payload {
+					t.Fatalf("got payload %q, want %q", payload, tt.payload)
+				}
+				return
+			}
+
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Fatalf("got error %v, want %q", err, tt.err)
+			}
+			var abiErr *Error
+			if stderrs.As(err, &abiErr) != (tt.status != StatusOK) {
+				t.Fatalf("got %T, want *Error: %v", err, tt.status != StatusOK)
+			}
+			if abiErr != nil && abiErr.Status != tt.status {
+				t.Fatalf("got status %v, want %v", abiErr.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestEncodeResolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		err    error
+		status Status
+	}{
+		{"ok", "type A {}", nil, StatusOK},
+		{"not found", "", fmt.Errorf("missing: %w", ErrNotFound), StatusNotFound},
+		{"file not found", "", fmt.Errorf("open a.apex: %w", fs.ErrNotExist), StatusNotFound},
+		{"error", "", fmt.Errorf("permission denied"), StatusError},
+		{"abi error", "", &Error{Status: StatusNotFound, Errors: errors.Errors{{Message: "gone"}}}, StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := EncodeResolve(tt.source, tt.err)
+			if Status(response[0]) != tt.status {
+				t.Fatalf("got status %v, want %v", Status(response[0]), tt.status)
+			}
+			payload, err := Decode(response)
+			if tt.err == nil {
+				if err != nil || string(payload) != tt.source {
+					t.Fatalf("got %q, %v", payload, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err.Error() {
+				t.Fatalf("got error %v, want %q", err, tt.err)
+			}
+			if stderrs.Is(err, ErrNotFound) != (tt.status == StatusNotFound) {
+				t.Fatalf("errors.Is(err, ErrNotFound) = %v", !(tt.status == StatusNotFound))
+			}
+		})
+	}
+}
+
+func TestDecodeResolve(t *testing.T) {
+	memory := map[uint64][]byte{
+		Pack(8, 10): Encode(StatusOK, []byte("type A {}")),
+		Pack(8, 20): EncodeErrors(StatusNotFound, fmt.Errorf("missing")),
+		Pack(8, 30): EncodeErrors(StatusError, fmt.Errorf("failed")),
+		Pack(8, 40): {},
+	}
+	read := func(ptr, size uint32) ([]byte, bool) {
+		response, ok := memory[Pack(ptr, size)]
+		return response, ok
+	}
+
+	tests := []struct {
+		name    string
+		ptrSize uint64
+		source  string
+		err     string
+	}{
+		{"ok", Pack(8, 10), "type A {}", ""},
+		{"not found", Pack(8, 20), "", "missing"},
+		{"error", Pack(8, 30), "", "failed"},
+		{"empty", Pack(8, 40), "", "empty response"},
+		{"no response", 0, "", "no response"},
+		{"out of range", Pack(1<<31, 10), "", "resolve response is out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := DecodeResolve(tt.ptrSize, read)
+			if tt.err == "" {
+				if err != nil || source != tt.source {
+					t.Fatalf("got %q, %v", source, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("got error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package abi
+
+import (
+	stderrs "errors"
+	"fmt"
+
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// Parse parses, validates and converts source and returns the response
+// of the parse function. Imports that do not exist are diagnostics of
+// the spec, other resolver failures fail the whole request with
+// StatusError.
+func Parse(source string, resolver parser.Resolver) []byte {
+	var failure error
+	resolve := func(location, from string) (string, error) {
+		src, err := resolver(location, from)
+		if err != nil && failure == nil && !isNotFound(err) {
+			failure = err
+		}
+		return src, err
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source,
+		Options: parser.ParseOptions{
+			NoSource: true,
+			Resolver: resolve,
+		},
+	})
+	if failure != nil {
+		return EncodeErrors(StatusError, failure)
+	}
+	if err != nil {
+		return EncodeErrors(StatusInvalid, err)
+	}
+
+	if errs := rules.Validate(doc, rules.Rules...); len(errs) > 0 {
+		return EncodeErrors(StatusInvalid, errs...)
+	}
+
+	ns, errs := model.Convert(doc)
+	if len(errs) > 0 {
+		return EncodeErrors(StatusInvalid, errs...)
+	}
+
+	jsonBytes, err := ns.MarshalJSON()
+	if err != nil {
+		return EncodeErrors(StatusError, err)
+	}
+	return Encode(StatusOK, jsonBytes)
+}
+
+// DecodeParse returns the result of a parse response. Diagnostics are
+// returned in the result, other failures as an error.
+func DecodeParse(response []byte) (*model.ParserResult, error) {
+	payload, err := Decode(response)
+	if err != nil {
+		var abiErr *Error
+		if stderrs.As(err, &abiErr) && abiErr.Status == StatusInvalid {
+			return &model.ParserResult{Errors: modelErrors(abiErr)}, nil
+		}
+		return nil, err
+	}
+
+	var ns model.Namespace
+	if err := ns.UnmarshalJSON(payload); err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+	return &model.ParserResult{Namespace: &ns}, nil
+}
+
+func modelErrors(e *Error) []model.Error {
+	errs := make([]model.Error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i].Message = err.Message
+		errs[i].Positions = make([]uint32, len(err.Positions))
+		for j, position := range err.Positions {
+			errs[i].Positions[j] = uint32(position)
+		}
+		errs[i].Locations = make([]model.Location, len(err.Locations))
+		for j, l := range err.Locations {
+			errs[i].Locations[j] = model.Location{
+				Line:   uint32(l.Line),
+				Column: uint32(l.Column),
+			}
+		}
+	}
+	return errs
+}
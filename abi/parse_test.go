@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package abi
+
+import (
+	stderrs "errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	imports := map[string]string{
+		"common": "type Common { id: string }",
+	}
+	resolver := func(location, from string) (string, error) {
+		switch location {
+		case "broken":
+			return "", fmt.Errorf("connection reset")
+		case "gone":
+			return "", fmt.Errorf("open gone.apex: %w", fs.ErrNotExist)
+		}
+		if source, ok := imports[location]; ok {
+			return source, nil
+		}
+		return "", &Error{Status: StatusNotFound}
+	}
+
+	tests := []struct {
+		name      string
+		source    string
+		status    Status
+		namespace string
+		errs      []string
+	}{
+		{
+			name:      "ok",
+			source:    "namespace \"test\"\nimport * from \"common\"\ntype A { c: Common }",
+			status:    StatusOK,
+			namespace: "test",
+		},
+		{
+			name:   "syntax error",
+			source: "namespace \"test\"\ntype {",
+			status: StatusInvalid,
+			errs:   []string{"Syntax Error"},
+		},
+		{
+			name:   "validation errors",
+			source: "namespace \"test\"\ntype A { b: B c: C }",
+			status: StatusInvalid,
+			errs:   []string{`unknown type "B"`, `unknown type "C"`},
+		},
+		{
+			name:   "import not found",
+			source: "namespace \"test\"\nimport * from \"missing\"",
+			status: StatusInvalid,
+			errs:   []string{"not found"},
+		},
+		{
+			name:   "import file not found",
+			source: "namespace \"test\"\nimport * from \"gone\"",
+			status: StatusInvalid,
+			errs:   []string{"open gone.apex"},
+		},
+		{
+			name:   "resolver failure",
+			source: "namespace \"test\"\nimport * from \"broken\"",
+			status: StatusError,
+			errs:   []string{"connection reset"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := Parse(tt.source, resolver)
+			if Status(response[0]) != tt.status {
+				t.Fatalf("got status %v, want %v: %s", Status(response[0]), tt.status, response[1:])
+			}
+
+			result, err := DecodeParse(response)
+			switch tt.status {
+			case StatusOK:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.Namespace == nil || result.Namespace.Name != tt.namespace {
+					t.Fatalf("got namespace %+v", result.Namespace)
+				}
+			case StatusInvalid:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(result.Errors) != len(tt.errs) {
+					t.Fatalf("got errors %+v, want %q", result.Errors, tt.errs)
+				}
+				for i, e := range result.Errors {
+					if !strings.Contains(e.Message, tt.errs[i]) {
+						t.Errorf("got error %q, want %q", e.Message, tt.errs[i])
+					}
+				}
+			default:
+				var abiErr *Error
+				if !stderrs.As(err, &abiErr) || abiErr.Status != tt.status {
+					t.Fatalf("got error %v, want status %v", err, tt.status)
+				}
+				if !strings.Contains(err.Error(), tt.errs[0]) {
+					t.Fatalf("got error %q, want %q", err, tt.errs[0])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response []byte
+		err      string
+	}{
+		{"empty", nil, "empty response"},
+		{"unknown status", Encode(Status(9), nil), "unknown response status 9"},
+		{"malformed namespace", Encode(StatusOK, []byte("{")), ""},
+		{"malformed errors", Encode(StatusInvalid, []byte("{")), "invalid invalid response: "},
+		{"not found", EncodeErrors(StatusNotFound, fmt.Errorf("missing")), "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DecodeParse(tt.response)
+			if err == nil {
+				t.Fatalf("expected an error, got %+v", result)
+			}
+			if !strings.HasPrefix(err.Error(), tt.err) {
+				t.Fatalf("got error %q, want %q", err, tt.err)
+			}
+		})
+	}
+}
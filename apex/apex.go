@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apex builds Apex specs in code, for specs generated from
+// other sources and for test fixtures:
+//
+//	ns := apex.NewNamespace("customers.v1")
+//	ns.Type("Customer").
+//		Field("id", apex.U64).
+//		Field("email", apex.String).Optional()
+//	doc, errs := ns.Document()
+//
+// The builder of a definition embeds the builder it was added to, so a
+// chain of calls can go on with the next field, value, parameter or
+// definition. Names, types and values are checked as they are added,
+// and Document and Namespace apply the validation rules to the result.
+package apex
+
+import (
+	"fmt"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// NamespaceBuilder builds the document of a namespace.
+type NamespaceBuilder struct {
+	def         *ast.NamespaceDefinition
+	definitions []ast.Node
+	rules       []rules.ValidationRule
+	errs        []error
+}
+
+// NewNamespace starts the document of the namespace name.
+func NewNamespace(name string) *NamespaceBuilder {
+	n := &NamespaceBuilder{rules: rules.Rules}
+	if name == "" {
+		n.errorf("missing namespace name")
+	}
+	n.def = ast.NewNamespaceDefinition(nil, ast.Ident(name), nil, nil)
+	return n
+}
+
+// Describe sets the description of the namespace.
+func (n *NamespaceBuilder) Describe(description string) *NamespaceBuilder {
+	n.def.Description = ast.Str(description)
+	return n
+}
+
+// Annotate adds an annotation to the namespace.
+func (n *NamespaceBuilder) Annotate(name string, args ...Argument) *NamespaceBuilder {
+	n.def.Annotations = append(n.def.Annotations, n.annotation(name, args))
+	return n
+}
+
+// Rules sets the validation rules of Document, which are rules.Rules
+// by default.
+func (n *NamespaceBuilder) Rules(validationRules ...rules.ValidationRule) *NamespaceBuilder {
+	n.rules = validationRules
+	return n
+}
+
+// Errors returns the errors found so far while building.
+func (n *NamespaceBuilder) Errors() []error {
+	return n.errs
+}
+
+// Document returns a copy of the document built so far, or the errors
+// found while building or validating it.
+func (n *NamespaceBuilder) Document() (*ast.Document, []error) {
+	if len(n.errs) > 0 {
+		return nil, n.errs
+	}
+	definitions := make([]ast.Node, 0, len(n.definitions)+1)
+	definitions = append(definitions, n.def)
+	definitions = append(definitions, n.definitions...)
+	doc := ast.NewDocument(nil, definitions).Clone()
+	if errs := rules.Validate(doc, n.rules...); len(errs) > 0 {
+		return nil, errs
+	}
+	return doc, nil
+}
+
+// Namespace returns the document built so far converted to a
+// namespace, or the errors found while building, validating or
+// converting it.
+func (n *NamespaceBuilder) Namespace() (*model.Namespace, []error) {
+	doc, errs := n.Document()
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return model.Convert(doc)
+}
+
+func (n *NamespaceBuilder) errorf(format string, args ...interface{}) {
+	n.errs = append(n.errs, fmt.Errorf(format, args...))
+}
+
+// ident returns the name node of value, which must be a valid name.
+func (n *NamespaceBuilder) ident(value string) *ast.Name {
+	if !isName(value) {
+		n.errorf("invalid name %q", value)
+	}
+	return ast.Ident(value)
+}
+
+// typeNode returns the node of t, which is the type of what.
+func (n *NamespaceBuilder) typeNode(what string, t Type) ast.Type {
+	node, err := t.build()
+	if err != nil {
+		n.errorf("%s: %v", what, err)
+	}
+	return node
+}
+
+// optional returns t made optional, which is the type of what.
+func (n *NamespaceBuilder) optional(what string, t ast.Type) ast.Type {
+	switch t.(type) {
+	case nil:
+		return nil
+	case *ast.Optional:
+		n.errorf("%s is already optional", what)
+		return t
+	}
+	return ast.OptionalOf(t)
+}
+
+// value returns the node of v, which is the value of what.
+func (n *NamespaceBuilder) value(what string, v interface{}) ast.Value {
+	if err := checkValue(v); err != nil {
+		n.errorf("%s: %v", what, err)
+		return nil
+	}
+	return ast.Clone(ast.ValueOf(v)).(ast.Value)
+}
+
+func (n *NamespaceBuilder) annotation(name string, args []Argument) *ast.Annotation {
+	arguments := make([]*ast.Argument, 0, len(args))
+	for _, arg := range args {
+		value := n.value(fmt.Sprintf("argument %q of @%s", arg.name, name), arg.value)
+		arguments = append(arguments, ast.NewArgument(nil, n.ident(arg.name), value))
+	}
+	return ast.NewAnnotation(nil, n.ident(name), arguments)
+}
+
+// locations returns the name nodes of directive locations such as
+// "TYPE" or "FIELD".
+func (n *NamespaceBuilder) locations(values []string) []*ast.Name {
+	names := make([]*ast.Name, len(values))
+	for i, value := range values {
+		var location model.DirectiveLocation
+		if err := location.FromString(value); err != nil {
+			n.errs = append(n.errs, err)
+		}
+		names[i] = ast.Ident(value)
+	}
+	return names
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apex_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/apex"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/printer"
+	"github.com/apexlang/apex-go/source"
+)
+
+func Example() {
+	ns := apex.NewNamespace("customers.v1")
+	ns.Type("Customer").
+		Describe("A customer of the store.").
+		Field("id", apex.U64).Annotate("key").
+		Field("email", apex.String).Optional().
+		Field("tier", apex.Named("Tier")).Default(apex.Ref("FREE")).
+		Enum("Tier").
+		Value("FREE", 0).
+		Value("PAID", 1).Display("Paid").
+		Interface("Customers").
+		Operation("get").Unary("id", apex.U64).Returns(apex.Named("Customer"))
+
+	doc, errs := ns.Document()
+	if len(errs) > 0 {
+		fmt.Println(errs)
+		return
+	}
+	printer.Fprint(os.Stdout, doc)
+	// Output:
+	// namespace "customers.v1"
+	//
+	// "A customer of the store."
+	// type Customer {
+	//   id: u64 @key
+	//   email: string?
+	//   tier: Tier = FREE
+	// }
+	//
+	// enum Tier {
+	//   FREE = 0
+	//   PAID = 1 as "Paid"
+	// }
+	//
+	// interface Customers {
+	//   get[id: u64]: Customer
+	// }
+}
+
+func TestNamespace(t *testing.T) {
+	const spec = `namespace "shop"
+  @version(major: 1)
+
+directive @path(value: string) on INTERFACE | OPERATION
+
+"Marks a service."
+directive @service on INTERFACE
+  require @path INTERFACE
+
+alias Key = string
+
+type Item {
+  id: Key
+  tags: [string] = ["new"]
+  prices: {string: f64}
+  note: string? @deprecated(reason: "unused")
+}
+
+enum Size {
+  SMALL = 0 as "Small"
+  LARGE = 1
+}
+
+union Entry = Item | Size
+
+func ping(): string
+
+interface Items @service @path(value: "/items") {
+  list(size: Size = LARGE, limit: i32?): stream Item @path(value: "/list")
+  put[item: Item]
+}
+`
+	expected := convert(t, spec)
+
+	ns := apex.NewNamespace("shop").Annotate("version", apex.Arg("major", 1))
+	ns.Directive("path", "INTERFACE", "OPERATION").Param("value", apex.String)
+	ns.Directive("service", "INTERFACE").Describe("Marks a service.").Require("path", "INTERFACE")
+	ns.Alias("Key", apex.String)
+	ns.Type("Item").
+		Field("id", apex.Named("Key")).
+		Field("tags", apex.List(apex.String)).Default([]interface{}{"new"}).
+		Field("prices", apex.Map(apex.String, apex.F64)).
+		Field("note", apex.String).Optional().Annotate("deprecated", apex.Arg("reason", "unused"))
+	ns.Enum("Size").
+		Value("SMALL", 0).Display("Small").
+		Value("LARGE", 1)
+	ns.Union("Entry", apex.Named("Item"), apex.Named("Size"))
+	ns.Func("ping").Returns(apex.String)
+	ns.Interface("Items").Annotate("service").Annotate("path", apex.Arg("value", "/items")).
+		Operation("list").
+		Param("size", apex.Named("Size")).Default(apex.Ref("LARGE")).
+		Param("limit", apex.I32).Optional().
+		Returns(apex.Stream(apex.Named("Item"))).Annotate("path", apex.Arg("value", "/list")).
+		Operation("put").Unary("item", apex.Named("Item"))
+
+	actual, errs := ns.Namespace()
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	a, _ := json.Marshal(actual)
+	e, _ := json.Marshal(expected)
+	if !bytes.Equal(a, e) {
+		t.Errorf("built namespace is\n%s\nwant\n%s", a, e)
+	}
+}
+
+func convert(t *testing.T, spec string) *model.Namespace {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource("spec.apex", []byte(spec))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns, errs := model.Convert(doc)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return ns
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(ns *apex.NamespaceBuilder)
+		err   string
+	}{
+		{
+			name:  "invalid name",
+			build: func(ns *apex.NamespaceBuilder) { ns.Type("my-type") },
+			err:   `invalid name "my-type"`,
+		},
+		{
+			name:  "invalid type name",
+			build: func(ns *apex.NamespaceBuilder) { ns.Alias("A", apex.List(apex.Named("1x"))) },
+			err:   `alias "A": invalid type name "1x"`,
+		},
+		{
+			name:  "missing type",
+			build: func(ns *apex.NamespaceBuilder) { ns.Type("T").Field("f", apex.Type{}) },
+			err:   `field "f": missing type`,
+		},
+		{
+			name:  "already optional",
+			build: func(ns *apex.NamespaceBuilder) { ns.Type("T").Field("f", apex.Optional(apex.String)).Optional() },
+			err:   `field "f" is already optional`,
+		},
+		{
+			name:  "unsupported value",
+			build: func(ns *apex.NamespaceBuilder) { ns.Type("T").Field("f", apex.String).Default(struct{}{}) },
+			err:   `default of field "f": unsupported value {} of type struct {}`,
+		},
+		{
+			name: "unary with parameters",
+			build: func(ns *apex.NamespaceBuilder) {
+				ns.Func("f").Param("a", apex.String).Unary("b", apex.String)
+			},
+			err: `operation "f" has parameters and cannot be unary`,
+		},
+		{
+			name:  "unknown location",
+			build: func(ns *apex.NamespaceBuilder) { ns.Directive("d", "NOWHERE") },
+			err:   `unknown value "NOWHERE" for DirectiveLocation`,
+		},
+		{
+			name:  "validation",
+			build: func(ns *apex.NamespaceBuilder) { ns.Type("T").Field("f", apex.Named("Missing")) },
+			err:   `unknown type "Missing"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := apex.NewNamespace("test")
+			tt.build(ns)
+			doc, errs := ns.Document()
+			if doc != nil || len(errs) != 1 {
+				t.Fatalf("Document() = %v, %v, want one error", doc, errs)
+			}
+			if !strings.Contains(errs[0].Error(), tt.err) {
+				t.Errorf("error %q does not contain %q", errs[0], tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apex
+
+import (
+	"fmt"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// AliasBuilder builds an alias.
+type AliasBuilder struct {
+	*NamespaceBuilder
+	def *ast.AliasDefinition
+}
+
+// Alias adds an alias of t.
+func (n *NamespaceBuilder) Alias(name string, t Type) *AliasBuilder {
+	def := ast.NewAliasDefinition(nil, n.ident(name), nil, n.typeNode(fmt.Sprintf("alias %q", name), t), nil)
+	n.definitions = append(n.definitions, def)
+	return &AliasBuilder{n, def}
+}
+
+// Describe sets the description of the alias.
+func (b *AliasBuilder) Describe(description string) *AliasBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the alias.
+func (b *AliasBuilder) Annotate(name string, args ...Argument) *AliasBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// TypeBuilder builds a type.
+type TypeBuilder struct {
+	*NamespaceBuilder
+	def *ast.TypeDefinition
+}
+
+// Type adds a type. Its fields are added with Field.
+func (n *NamespaceBuilder) Type(name string) *TypeBuilder {
+	def := ast.NewTypeDefinition(nil, n.ident(name), nil, nil, nil, nil)
+	n.definitions = append(n.definitions, def)
+	return &TypeBuilder{n, def}
+}
+
+// Describe sets the description of the type.
+func (b *TypeBuilder) Describe(description string) *TypeBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the type.
+func (b *TypeBuilder) Annotate(name string, args ...Argument) *TypeBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Field adds a field of type t.
+func (b *TypeBuilder) Field(name string, t Type) *FieldBuilder {
+	def := ast.NewFieldDefinition(nil, b.ident(name), nil, b.typeNode(fmt.Sprintf("field %q", name), t), nil, nil)
+	b.def.Fields = append(b.def.Fields, def)
+	return &FieldBuilder{b, def}
+}
+
+// FieldBuilder builds a field of a type.
+type FieldBuilder struct {
+	*TypeBuilder
+	def *ast.FieldDefinition
+}
+
+// Describe sets the description of the field.
+func (b *FieldBuilder) Describe(description string) *FieldBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the field.
+func (b *FieldBuilder) Annotate(name string, args ...Argument) *FieldBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Optional makes the type of the field optional.
+func (b *FieldBuilder) Optional() *FieldBuilder {
+	b.def.Type = b.optional(fmt.Sprintf("field %q", b.def.Name.Value), b.def.Type)
+	return b
+}
+
+// Default sets the default value of the field, see Arg for the values
+// allowed.
+func (b *FieldBuilder) Default(value interface{}) *FieldBuilder {
+	b.def.Default = b.value(fmt.Sprintf("default of field %q", b.def.Name.Value), value)
+	return b
+}
+
+// EnumBuilder builds an enum.
+type EnumBuilder struct {
+	*NamespaceBuilder
+	def *ast.EnumDefinition
+}
+
+// Enum adds an enum. Its values are added with Value.
+func (n *NamespaceBuilder) Enum(name string) *EnumBuilder {
+	def := ast.NewEnumDefinition(nil, n.ident(name), nil, nil, nil)
+	n.definitions = append(n.definitions, def)
+	return &EnumBuilder{n, def}
+}
+
+// Describe sets the description of the enum.
+func (b *EnumBuilder) Describe(description string) *EnumBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the enum.
+func (b *EnumBuilder) Annotate(name string, args ...Argument) *EnumBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Value adds the value name with index.
+func (b *EnumBuilder) Value(name string, index int) *EnumValueBuilder {
+	def := ast.NewEnumValueDefinition(nil, b.ident(name), nil, ast.NewIntValue(nil, index), nil, nil)
+	b.def.Values = append(b.def.Values, def)
+	return &EnumValueBuilder{b, def}
+}
+
+// EnumValueBuilder builds a value of an enum.
+type EnumValueBuilder struct {
+	*EnumBuilder
+	def *ast.EnumValueDefinition
+}
+
+// Describe sets the description of the value.
+func (b *EnumValueBuilder) Describe(description string) *EnumValueBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the value.
+func (b *EnumValueBuilder) Annotate(name string, args ...Argument) *EnumValueBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Display sets the display name of the value.
+func (b *EnumValueBuilder) Display(display string) *EnumValueBuilder {
+	b.def.Display = ast.Str(display)
+	return b
+}
+
+// UnionBuilder builds a union.
+type UnionBuilder struct {
+	*NamespaceBuilder
+	def *ast.UnionDefinition
+}
+
+// Union adds a union of types.
+func (n *NamespaceBuilder) Union(name string, types ...Type) *UnionBuilder {
+	members := make([]ast.Type, len(types))
+	for i, t := range types {
+		members[i] = n.typeNode(fmt.Sprintf("member of union %q", name), t)
+	}
+	def := ast.NewUnionDefinition(nil, n.ident(name), nil, nil, members)
+	n.definitions = append(n.definitions, def)
+	return &UnionBuilder{n, def}
+}
+
+// Describe sets the description of the union.
+func (b *UnionBuilder) Describe(description string) *UnionBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the union.
+func (b *UnionBuilder) Annotate(name string, args ...Argument) *UnionBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// InterfaceBuilder builds an interface, or the functions of the
+// namespace when it has no interface.
+type InterfaceBuilder struct {
+	*NamespaceBuilder
+	def *ast.InterfaceDefinition
+}
+
+// Interface adds an interface. Its operations are added with
+// Operation.
+func (n *NamespaceBuilder) Interface(name string) *InterfaceBuilder {
+	def := ast.NewInterfaceDefinition(nil, n.ident(name), nil, nil, nil)
+	n.definitions = append(n.definitions, def)
+	return &InterfaceBuilder{n, def}
+}
+
+// Func adds a function. Operation on the builder of a function adds
+// another function.
+func (n *NamespaceBuilder) Func(name string) *OperationBuilder {
+	return (&InterfaceBuilder{NamespaceBuilder: n}).Operation(name)
+}
+
+// Describe sets the description of the interface.
+func (b *InterfaceBuilder) Describe(description string) *InterfaceBuilder {
+	if b.def == nil {
+		b.errorf("functions have no interface to describe")
+		return b
+	}
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the interface.
+func (b *InterfaceBuilder) Annotate(name string, args ...Argument) *InterfaceBuilder {
+	if b.def == nil {
+		b.errorf("functions have no interface to annotate")
+		return b
+	}
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Operation adds an operation, which returns void until Returns is
+// called.
+func (b *InterfaceBuilder) Operation(name string) *OperationBuilder {
+	def := ast.NewOperationDefinition(nil, b.ident(name), nil, ast.TypeName("void"), nil, false, nil)
+	if b.def == nil {
+		b.definitions = append(b.definitions, def)
+	} else {
+		b.def.Operations = append(b.def.Operations, def)
+	}
+	return &OperationBuilder{b, def}
+}
+
+// OperationBuilder builds an operation of an interface or a function.
+type OperationBuilder struct {
+	*InterfaceBuilder
+	def *ast.OperationDefinition
+}
+
+// Describe sets the description of the operation.
+func (b *OperationBuilder) Describe(description string) *OperationBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the operation.
+func (b *OperationBuilder) Annotate(name string, args ...Argument) *OperationBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Returns sets the return type of the operation.
+func (b *OperationBuilder) Returns(t Type) *OperationBuilder {
+	b.def.Type = b.typeNode(fmt.Sprintf("return type of operation %q", b.def.Name.Value), t)
+	return b
+}
+
+// Param adds a parameter of type t.
+func (b *OperationBuilder) Param(name string, t Type) *ParameterBuilder {
+	if b.def.Unary {
+		b.errorf("operation %q is unary and cannot have more parameters", b.def.Name.Value)
+	}
+	return b.param(name, t)
+}
+
+// Unary adds the single parameter of a unary operation, which takes
+// the parameter itself rather than a list of parameters.
+func (b *OperationBuilder) Unary(name string, t Type) *ParameterBuilder {
+	if len(b.def.Parameters) > 0 {
+		b.errorf("operation %q has parameters and cannot be unary", b.def.Name.Value)
+	}
+	b.def.Unary = true
+	return b.param(name, t)
+}
+
+func (b *OperationBuilder) param(name string, t Type) *ParameterBuilder {
+	def := ast.NewParameterDefinition(nil, b.ident(name), nil, b.typeNode(fmt.Sprintf("parameter %q", name), t), nil, nil)
+	b.def.Parameters = append(b.def.Parameters, def)
+	return &ParameterBuilder{b, def}
+}
+
+// ParameterBuilder builds a parameter of an operation.
+type ParameterBuilder struct {
+	*OperationBuilder
+	def *ast.ParameterDefinition
+}
+
+// Describe sets the description of the parameter.
+func (b *ParameterBuilder) Describe(description string) *ParameterBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Annotate adds an annotation to the parameter.
+func (b *ParameterBuilder) Annotate(name string, args ...Argument) *ParameterBuilder {
+	b.def.Annotations = append(b.def.Annotations, b.annotation(name, args))
+	return b
+}
+
+// Optional makes the type of the parameter optional.
+func (b *ParameterBuilder) Optional() *ParameterBuilder {
+	b.def.Type = b.optional(fmt.Sprintf("parameter %q", b.def.Name.Value), b.def.Type)
+	return b
+}
+
+// Default sets the default value of the parameter, see Arg for the
+// values allowed.
+func (b *ParameterBuilder) Default(value interface{}) *ParameterBuilder {
+	b.def.Default = b.value(fmt.Sprintf("default of parameter %q", b.def.Name.Value), value)
+	return b
+}
+
+// DirectiveBuilder builds a directive.
+type DirectiveBuilder struct {
+	*NamespaceBuilder
+	def *ast.DirectiveDefinition
+}
+
+// Directive adds a directive allowed on locations such as "TYPE" or
+// "FIELD".
+func (n *NamespaceBuilder) Directive(name string, locations ...string) *DirectiveBuilder {
+	def := ast.NewDirectiveDefinition(nil, n.ident(name), nil, nil, n.locations(locations), nil)
+	n.definitions = append(n.definitions, def)
+	return &DirectiveBuilder{n, def}
+}
+
+// Describe sets the description of the directive.
+func (b *DirectiveBuilder) Describe(description string) *DirectiveBuilder {
+	b.def.Description = ast.Str(description)
+	return b
+}
+
+// Param adds a parameter of type t.
+func (b *DirectiveBuilder) Param(name string, t Type) *DirectiveBuilder {
+	def := ast.NewParameterDefinition(nil, b.ident(name), nil, b.typeNode(fmt.Sprintf("parameter %q", name), t), nil, nil)
+	b.def.Parameters = append(b.def.Parameters, def)
+	return b
+}
+
+// Require requires that wherever the directive is used, the directive
+// named directive is also used on locations, such as "INTERFACE" for
+// the interface of an operation.
+func (b *DirectiveBuilder) Require(directive string, locations ...string) *DirectiveBuilder {
+	require := ast.NewDirectiveRequire(nil, b.ident(directive), b.locations(locations))
+	b.def.Requires = append(b.def.Requires, require)
+	return b
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/printer"
+)
+
+// Type is a reference to a type, such as a scalar, a named type or a
+// list. The zero Type is invalid.
+type Type struct {
+	node ast.Type
+	err  error
+}
+
+// The built-in scalar types.
+var (
+	String   = Named("string")
+	Bool     = Named("bool")
+	I8       = Named("i8")
+	I16      = Named("i16")
+	I32      = Named("i32")
+	I64      = Named("i64")
+	U8       = Named("u8")
+	U16      = Named("u16")
+	U32      = Named("u32")
+	U64      = Named("u64")
+	F32      = Named("f32")
+	F64      = Named("f64")
+	Bytes    = Named("bytes")
+	Datetime = Named("datetime")
+	Any      = Named("any")
+	Raw      = Named("raw")
+)
+
+// Named returns a reference to the type, enum, union or alias name.
+func Named(name string) Type {
+	if !isName(name) {
+		return Type{err: fmt.Errorf("invalid type name %q", name)}
+	}
+	return Type{node: ast.TypeName(name)}
+}
+
+// List returns a list of t.
+func List(t Type) Type {
+	if t.node == nil {
+		return t
+	}
+	return Type{node: ast.ListOf(t.node)}
+}
+
+// Map returns a map from key to value.
+func Map(key, value Type) Type {
+	if key.node == nil {
+		return key
+	}
+	if value.node == nil {
+		return value
+	}
+	return Type{node: ast.MapOf(key.node, value.node)}
+}
+
+// Optional returns an optional t.
+func Optional(t Type) Type {
+	if t.node == nil {
+		return t
+	}
+	if _, ok := t.node.(*ast.Optional); ok {
+		return Type{err: fmt.Errorf("type %s is already optional", t)}
+	}
+	return Type{node: ast.OptionalOf(t.node)}
+}
+
+// Stream returns a stream of t, which is only valid as the type of a
+// parameter or a return value.
+func Stream(t Type) Type {
+	if t.node == nil {
+		return t
+	}
+	return Type{node: ast.StreamOf(t.node)}
+}
+
+// String returns t as it is written in a spec.
+func (t Type) String() string {
+	if t.node == nil {
+		return "<invalid>"
+	}
+	var sb strings.Builder
+	printer.Fprint(&sb, t.node)
+	return sb.String()
+}
+
+// build returns a copy of the node of t, so that no two definitions
+// share one.
+func (t Type) build() (ast.Type, error) {
+	if t.node == nil {
+		if t.err != nil {
+			return nil, t.err
+		}
+		return nil, fmt.Errorf("missing type")
+	}
+	return ast.Clone(t.node).(ast.Type), nil
+}
+
+// Argument is an argument of an annotation.
+type Argument struct {
+	name  string
+	value interface{}
+}
+
+// Arg returns the argument name with value, which may be a bool, an
+// integer, a float, a string, a Ref, a []interface{} or a
+// map[string]interface{} of those.
+func Arg(name string, value interface{}) Argument {
+	return Argument{name, value}
+}
+
+// Ref returns a reference to an enum value, for use as a default value
+// or an argument.
+func Ref(name string) ast.Value {
+	return ast.NewEnumValue(nil, name)
+}
+
+// checkValue returns an error if v cannot be written in a spec, instead
+// of letting ast.ValueOf panic.
+func checkValue(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		return fmt.Errorf("missing value")
+	case ast.Value, bool, int, int32, int64, float32, float64, string:
+		return nil
+	case []interface{}:
+		for _, item := range v {
+			if err := checkValue(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		for key, item := range v {
+			if !isName(key) {
+				return fmt.Errorf("invalid field name %q", key)
+			}
+			if err := checkValue(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported value %v of type %T", v, v)
+}
+
+// isName reports whether s is a name as the lexer reads it.
+func isName(s string) bool {
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c == '_' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+	return true
+}
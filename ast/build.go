@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"sort"
+)
+
+// The functions below build nodes without a location, for rewrites and
+// specs generated in code. Use the New functions to set locations.
+
+// Ident returns a name node.
+func Ident(value string) *Name {
+	return NewName(nil, value)
+}
+
+// Str returns a string value, as used for descriptions.
+func Str(value string) *StringValue {
+	return NewStringValue(nil, value)
+}
+
+// TypeName returns a reference to the named type.
+func TypeName(name string) *Named {
+	return NewNamed(nil, Ident(name))
+}
+
+// ListOf returns a list of t.
+func ListOf(t Type) *ListType {
+	return NewListType(nil, t)
+}
+
+// MapOf returns a map from key to value.
+func MapOf(key, value Type) *MapType {
+	return NewMapType(nil, key, value)
+}
+
+// OptionalOf returns an optional t.
+func OptionalOf(t Type) *Optional {
+	return NewOptional(nil, t)
+}
+
+// StreamOf returns a stream of t.
+func StreamOf(t Type) *Stream {
+	return NewStream(nil, t)
+}
+
+// ValueOf returns the value node for v, which may be a Value, nil, a
+// bool, an integer, a float, a string, a []interface{} or a
+// map[string]interface{} of those. Map keys are sorted. It panics for
+// other types. Enum values are built with NewEnumValue.
+func ValueOf(v interface{}) Value {
+	switch v := v.(type) {
+	case Value:
+		return v
+	case nil:
+		return nil
+	case bool:
+		return NewBooleanValue(nil, v)
+	case int:
+		return NewIntValue(nil, v)
+	case int32:
+		return NewIntValue(nil, int(v))
+	case int64:
+		return NewIntValue(nil, int(v))
+	case float32:
+		return NewFloatValue(nil, float64(v))
+	case float64:
+		return NewFloatValue(nil, v)
+	case string:
+		return NewStringValue(nil, v)
+	case []interface{}:
+		values := make([]Value, len(v))
+		for i, item := range v {
+			values[i] = ValueOf(item)
+		}
+		return NewListValue(nil, values)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]*ObjectField, len(keys))
+		for i, key := range keys {
+			fields[i] = NewObjectField(nil, Ident(key), ValueOf(v[key]))
+		}
+		return NewObjectValue(nil, fields)
+	}
+	panic(fmt.Sprintf("ast: cannot build a value from %T", v))
+}
+
+// Arg returns an annotation argument with the value of v, see ValueOf.
+func Arg(name string, v interface{}) *Argument {
+	return NewArgument(nil, Ident(name), ValueOf(v))
+}
+
+// Annotate returns an annotation such as @deprecated or
+// @rename(go: "ID").
+func Annotate(name string, arguments ...*Argument) *Annotation {
+	return NewAnnotation(nil, Ident(name), arguments)
+}
+
+// Field returns a field of a type.
+func Field(name string, t Type, annotations ...*Annotation) *FieldDefinition {
+	return NewFieldDefinition(nil, Ident(name), nil, t, nil, annotations)
+}
+
+// Param returns a parameter of an operation or directive.
+func Param(name string, t Type, annotations ...*Annotation) *ParameterDefinition {
+	return NewParameterDefinition(nil, Ident(name), nil, t, nil, annotations)
+}
+
+// AddAnnotation appends annotation unless the node already has an
+// annotation with the same name. It returns true if it was added.
+func (a *AnnotatedNode) AddAnnotation(annotation *Annotation) bool {
+	if a.Annotation(annotation.Name.Value) != nil {
+		return false
+	}
+	a.Annotations = append(a.Annotations, annotation)
+	return true
+}
+
+// RemoveAnnotation removes the annotations with name and returns true if
+// there were any.
+func (a *AnnotatedNode) RemoveAnnotation(name string) bool {
+	kept := a.Annotations[:0]
+	for _, annotation := range a.Annotations {
+		if annotation.Name.Value != name {
+			kept = append(kept, annotation)
+		}
+	}
+	removed := len(kept) < len(a.Annotations)
+	for i := len(kept); i < len(a.Annotations); i++ {
+		a.Annotations[i] = nil
+	}
+	a.Annotations = kept
+	return removed
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+//go:generate go run gen_clone.go
+
+// Equal returns true if a and b are nodes of the same type with equal
+// fields, comparing locations unless ignoreLocations is set.
+func Equal(a, b Node, ignoreLocations bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if e, ok := a.(interface {
+		Equal(other Node, ignoreLocations bool) bool
+	}); ok {
+		return e.Equal(b, ignoreLocations)
+	}
+	return false
+}
+
+// Clone returns a copy of l sharing its source.
+func (l *Location) Clone() *Location {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	return &c
+}
+
+// Equal returns true if l and other have the same range in sources with
+// the same name.
+func (l *Location) Equal(other *Location) bool {
+	if l == nil || other == nil {
+		return l == other
+	}
+	return l.Start == other.Start && l.End == other.End &&
+		l.sourceName() == other.sourceName()
+}
+
+func (l *Location) sourceName() string {
+	if l.Source == nil {
+		return ""
+	}
+	return l.Source.Name
+}
+
+// Clone returns a deep copy of d. The origins of imported definitions
+// refer to the copies of the definitions and imports.
+func (d *Document) Clone() *Document {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	c.Loc = d.Loc.Clone()
+	c.Definitions = cloneList(d.Definitions)
+	c.Origins = nil
+	if d.Origins != nil {
+		copies := make(map[Node]Node, len(d.Definitions))
+		for i, def := range d.Definitions {
+			copies[def] = c.Definitions[i]
+		}
+		c.Origins = make(map[Node]*Origin, len(d.Origins))
+		for def, o := range d.Origins {
+			origin := *o
+			if imp, ok := copies[o.Import].(*ImportDefinition); ok {
+				origin.Import = imp
+			}
+			if dup, ok := copies[def]; ok {
+				c.Origins[dup] = &origin
+			}
+		}
+	}
+	return &c
+}
+
+// Equal returns true if other is a Document with equal definitions,
+// comparing locations unless ignoreLocations is set. Origins are not
+// compared.
+func (d *Document) Equal(other Node, ignoreLocations bool) bool {
+	o, ok := other.(*Document)
+	if !ok || d == nil || o == nil {
+		return ok && d == o
+	}
+	return d.Kind == o.Kind &&
+		(ignoreLocations || d.Loc.Equal(o.Loc)) &&
+		equalList(d.Definitions, o.Definitions, ignoreLocations)
+}
+
+// cloneAs returns a deep copy of n with the same type.
+func cloneAs[T Node](n T) T {
+	if isNil(n) {
+		return n
+	}
+	return Clone(n).(T)
+}
+
+func cloneList[T Node](list []T) []T {
+	if list == nil {
+		return nil
+	}
+	c := make([]T, len(list))
+	for i, n := range list {
+		c[i] = cloneAs(n)
+	}
+	return c
+}
+
+func equalAs[T Node](a, b T, ignoreLocations bool) bool {
+	if isNil(a) || isNil(b) {
+		return isNil(a) && isNil(b)
+	}
+	return Equal(a, b, ignoreLocations)
+}
+
+// equalList compares lists element by element. A nil list equals an
+// empty one.
+func equalList[T Node](a, b []T, ignoreLocations bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equalAs(a[i], b[i], ignoreLocations) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+)
+
+func TestCloneAndEqual(t *testing.T) {
+	body, err := os.ReadFile("../model.axdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := parse(string(body))
+	c := doc.Clone()
+	if !doc.Equal(c, false) || !ast.Equal(c, doc, false) {
+		t.Fatal("clone is not equal to the document")
+	}
+
+	// Changing the clone leaves the document alone.
+	ast.Inspect(c, func(n ast.Node) bool {
+		if f, ok := n.(*ast.FieldDefinition); ok {
+			f.Name.Value += "_"
+			f.Annotations = append(f.Annotations, ast.Annotate("changed"))
+		}
+		return true
+	})
+	if doc.Equal(c, true) {
+		t.Fatal("document changed along with its clone")
+	}
+	ast.Inspect(doc, func(n ast.Node) bool {
+		if a, ok := n.(*ast.Annotation); ok && a.Name.Value == "changed" {
+			t.Fatal("document shares annotations with its clone")
+		}
+		return true
+	})
+
+	// The same definitions at other positions are equal when locations
+	// are ignored.
+	moved := parse("\n\n" + string(body))
+	if doc.Equal(moved, false) {
+		t.Error("documents at different positions are equal")
+	}
+	if !doc.Equal(moved, true) {
+		t.Error("documents with the same definitions are not equal")
+	}
+}
+
+func TestImportCopies(t *testing.T) {
+	imported := `namespace "common"
+
+type Page @paged {
+  cursor: string
+}
+`
+	cache := parser.NewImportCache()
+	parseWithImport := func() *ast.Document {
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: "namespace \"users\"\n\nimport { Page as UserPage } from \"common.apex\"\nimport * from \"common.apex\"\n",
+			Options: parser.ParseOptions{
+				Resolver: func(location, from string) (string, error) {
+					return imported, nil
+				},
+				ImportCache: cache,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+
+	doc := parseWithImport()
+	ast.Inspect(doc, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeDefinition:
+			n.Fields[0].Name.Value = "changed"
+			n.RemoveAnnotation("paged")
+		}
+		return true
+	})
+
+	// The renamed and the imported type are copies, so the cached
+	// import is unchanged.
+	for _, def := range parseWithImport().Definitions {
+		if typ, ok := def.(*ast.TypeDefinition); ok {
+			if typ.Fields[0].Name.Value != "cursor" || typ.Annotation("paged") == nil {
+				t.Errorf("import of %s was changed", typ.Name.Value)
+			}
+		}
+	}
+}
@@ -47,7 +47,7 @@ var _ Definition = (*NamespaceDefinition)(nil)
 
 type NamespaceDefinition struct {
 	BaseNode
-	Name        *Name        `json:" name"`
+	Name        *Name        `json:"name"`
 	Description *StringValue `json:"description,omitempty"` // Optional
 	AnnotatedNode
 }
@@ -62,8 +62,10 @@ func NewNamespaceDefinition(loc *Location, name *Name, description *StringValue,
 }
 
 func (d *NamespaceDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitNamespace(context)
-	VisitAnnotations(context, visitor, d.Annotations)
+	context.visit(visitor.VisitNamespace)
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 }
 
 // AliasDefinition implements Node, Definition
@@ -88,8 +90,10 @@ func NewAliasDefinition(loc *Location, name *Name, description *StringValue, t T
 }
 
 func (d *AliasDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitAlias(context)
-	VisitAnnotations(context, visitor, d.Annotations)
+	context.visit(visitor.VisitAlias)
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 }
 
 // ImportDefinition implements Node, Definition
@@ -116,8 +120,10 @@ func NewImportDefinition(loc *Location, description *StringValue, all bool, name
 }
 
 func (d *ImportDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitImport(context)
-	VisitAnnotations(context, visitor, d.Annotations)
+	context.visit(visitor.VisitImport)
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 }
 
 // TypeDefinition implements Node, Definition
@@ -144,21 +150,28 @@ func NewTypeDefinition(loc *Location, name *Name, description *StringValue, inte
 }
 
 func (d *TypeDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitTypeBefore(context)
-	visitor.VisitType(context)
+	context.visit(visitor.VisitTypeBefore)
+	context.visit(visitor.VisitType)
 
-	c := context
-	c.Fields = context.Type.Fields
-	visitor.VisitTypeFieldsBefore(c)
-	for i, field := range c.Fields {
-		c.FieldIndex = i
-		c.Field = field
-		field.Accept(c, visitor)
-	}
-	visitor.VisitTypeFieldsAfter(c)
+	if context.descend() {
+		c := context
+		c.Fields = context.Type.Fields
+		c.visit(visitor.VisitTypeFieldsBefore)
+		if c.descend() {
+			for i, field := range c.Fields {
+				if !c.visits(field) {
+					continue
+				}
+				c.FieldIndex = i
+				c.Field = field
+				field.Accept(c, visitor)
+			}
+		}
+		c.visitAfter(visitor.VisitTypeFieldsAfter)
 
-	VisitAnnotations(context, visitor, d.Annotations)
-	visitor.VisitTypeAfter(context)
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
+	context.visitAfter(visitor.VisitTypeAfter)
 }
 
 type ValuedDefinition struct {
@@ -187,8 +200,10 @@ func NewFieldDefinition(loc *Location, name *Name, description *StringValue, t T
 }
 
 func (d *FieldDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitTypeField(context)
-	VisitAnnotations(context, visitor, d.Annotations)
+	context.visit(visitor.VisitTypeField)
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 }
 
 // RoleDefinition implements Node, Definition
@@ -213,20 +228,27 @@ func NewInterfaceDefinition(loc *Location, name *Name, description *StringValue,
 }
 
 func (d *InterfaceDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitInterfaceBefore(context)
-	visitor.VisitInterface(context)
+	context.visit(visitor.VisitInterfaceBefore)
+	context.visit(visitor.VisitInterface)
 
-	c := context
-	c.Operations = c.Interface.Operations
-	visitor.VisitOperationsBefore(c)
-	for _, oper := range c.Operations {
-		c.Operation = oper
-		oper.Accept(c, visitor)
-	}
-	visitor.VisitOperationsAfter(c)
+	if context.descend() {
+		c := context
+		c.Operations = c.Interface.Operations
+		c.visit(visitor.VisitOperationsBefore)
+		if c.descend() {
+			for _, oper := range c.Operations {
+				if !c.visits(oper) {
+					continue
+				}
+				c.Operation = oper
+				oper.Accept(c, visitor)
+			}
+		}
+		c.visitAfter(visitor.VisitOperationsAfter)
 
-	VisitAnnotations(context, visitor, d.Annotations)
-	visitor.VisitInterfaceAfter(context)
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
+	context.visitAfter(visitor.VisitInterfaceAfter)
 }
 
 // OperationDefinition implements Node, Definition
@@ -260,27 +282,35 @@ func (o *OperationDefinition) IsUnary() bool {
 
 func (d *OperationDefinition) Accept(context Context, visitor Visitor) {
 	if context.Interface != nil {
-		visitor.VisitOperationBefore(context)
-		visitor.VisitOperation(context)
+		context.visit(visitor.VisitOperationBefore)
+		context.visit(visitor.VisitOperation)
 	} else {
-		visitor.VisitFunctionBefore(context)
-		visitor.VisitFunction(context)
+		context.visit(visitor.VisitFunctionBefore)
+		context.visit(visitor.VisitFunction)
 	}
 
-	c := context
-	c.Parameters = c.Operation.Parameters
-	visitor.VisitParametersBefore(context)
-	for _, param := range c.Parameters {
-		c.Parameter = param
-		param.Accept(c, visitor)
-	}
-	visitor.VisitParametersAfter(c)
+	if context.descend() {
+		c := context
+		c.Operation = d
+		c.Parameters = d.Parameters
+		c.visit(visitor.VisitParametersBefore)
+		if c.descend() {
+			for _, param := range c.Parameters {
+				if !c.visits(param) {
+					continue
+				}
+				c.Parameter = param
+				param.Accept(c, visitor)
+			}
+		}
+		c.visitAfter(visitor.VisitParametersAfter)
 
-	VisitAnnotations(context, visitor, d.Annotations)
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 	if context.Interface != nil {
-		visitor.VisitOperationAfter(context)
+		context.visitAfter(visitor.VisitOperationAfter)
 	} else {
-		visitor.VisitFunctionAfter(context)
+		context.visitAfter(visitor.VisitFunctionAfter)
 	}
 }
 
@@ -302,11 +332,13 @@ func NewParameterDefinition(loc *Location, name *Name, description *StringValue,
 
 func (d *ParameterDefinition) Accept(context Context, visitor Visitor) {
 	if context.Operation != nil {
-		visitor.VisitParameter(context)
+		context.visit(visitor.VisitParameter)
 	} else if context.Directive != nil {
-		visitor.VisitDirectiveParameter(context)
+		context.visit(visitor.VisitDirectiveParameter)
+	}
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
 	}
-	VisitAnnotations(context, visitor, d.Annotations)
 }
 
 // UnionDefinition implements Node, Definition
@@ -331,8 +363,10 @@ func NewUnionDefinition(loc *Location, name *Name, description *StringValue, ann
 }
 
 func (d *UnionDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitUnion(context)
-	VisitAnnotations(context, visitor, d.Annotations)
+	context.visit(visitor.VisitUnion)
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 }
 
 // EnumDefinition implements Node, Definition
@@ -357,20 +391,27 @@ func NewEnumDefinition(loc *Location, name *Name, description *StringValue, anno
 }
 
 func (d *EnumDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitEnumBefore(context)
-	visitor.VisitEnum(context)
+	context.visit(visitor.VisitEnumBefore)
+	context.visit(visitor.VisitEnum)
 
-	c := context
-	c.EnumValues = c.Enum.Values
-	visitor.VisitEnumValuesBefore(c)
-	for _, enumValue := range c.EnumValues {
-		c.EnumValue = enumValue
-		enumValue.Accept(c, visitor)
-	}
-	visitor.VisitEnumValuesAfter(c)
+	if context.descend() {
+		c := context
+		c.EnumValues = c.Enum.Values
+		c.visit(visitor.VisitEnumValuesBefore)
+		if c.descend() {
+			for _, enumValue := range c.EnumValues {
+				if !c.visits(enumValue) {
+					continue
+				}
+				c.EnumValue = enumValue
+				enumValue.Accept(c, visitor)
+			}
+		}
+		c.visitAfter(visitor.VisitEnumValuesAfter)
 
-	VisitAnnotations(context, visitor, d.Annotations)
-	visitor.VisitEnumAfter(context)
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
+	context.visitAfter(visitor.VisitEnumAfter)
 }
 
 // EnumValueDefinition implements Node, Definition
@@ -397,8 +438,10 @@ func NewEnumValueDefinition(loc *Location, name *Name, description *StringValue,
 }
 
 func (d *EnumValueDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitEnumValue(context)
-	VisitAnnotations(context, visitor, d.Annotations)
+	context.visit(visitor.VisitEnumValue)
+	if context.descend() {
+		VisitAnnotations(context, visitor, d.Annotations)
+	}
 }
 
 // DirectiveDefinition implements Node, Definition
@@ -425,19 +468,26 @@ func NewDirectiveDefinition(loc *Location, name *Name, description *StringValue,
 }
 
 func (d *DirectiveDefinition) Accept(context Context, visitor Visitor) {
-	visitor.VisitDirectiveBefore(context)
-	visitor.VisitDirective(context)
+	context.visit(visitor.VisitDirectiveBefore)
+	context.visit(visitor.VisitDirective)
 
-	c := context
-	c.Parameters = c.Directive.Parameters
-	visitor.VisitDirectiveParametersBefore(c)
-	for _, param := range c.Parameters {
-		c.Parameter = param
-		param.Accept(c, visitor)
+	if context.descend() {
+		c := context
+		c.Parameters = c.Directive.Parameters
+		c.visit(visitor.VisitDirectiveParametersBefore)
+		if c.descend() {
+			for _, param := range c.Parameters {
+				if !c.visits(param) {
+					continue
+				}
+				c.Parameter = param
+				param.Accept(c, visitor)
+			}
+		}
+		c.visitAfter(visitor.VisitDirectiveParametersAfter)
 	}
-	visitor.VisitDirectiveParametersAfter(c)
 
-	visitor.VisitDirectiveAfter(context)
+	context.visitAfter(visitor.VisitDirectiveAfter)
 }
 
 func VisitAnnotations(
@@ -449,13 +499,19 @@ func VisitAnnotations(
 		return
 	}
 
-	visitor.VisitAnnotationsBefore(context)
-	for _, a := range annotations {
-		c := context
-		c.Annotation = a
-		visitor.VisitAnnotationBefore(c)
-		a.Accept(c, visitor)
-		visitor.VisitAnnotationAfter(c)
+	context.visit(visitor.VisitAnnotationsBefore)
+	if context.descend() {
+		for _, a := range annotations {
+			if context.Stopped() {
+				break
+			}
+			c := context
+			c.Annotation = a
+			c.visit(visitor.VisitAnnotationBefore)
+			a.Accept(c, visitor)
+			c.descend()
+			c.visitAfter(visitor.VisitAnnotationAfter)
+		}
 	}
-	visitor.VisitAnnotationsAfter(context)
+	context.visitAfter(visitor.VisitAnnotationsAfter)
 }
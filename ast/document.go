@@ -24,6 +24,27 @@ import (
 type Document struct {
 	BaseNode
 	Definitions []Node `json:"definitions"`
+	// Origins maps the definitions added by imports to where they
+	// were defined.
+	Origins map[Node]*Origin `json:"-"`
+}
+
+// Origin tells where an imported definition was defined.
+type Origin struct {
+	// Import is the import of the document that added the definition.
+	Import *ImportDefinition
+	// Definition is the definition in the document that defines it,
+	// following imports of imports. Named imports add a copy of it
+	// under the imported name.
+	Definition Node
+}
+
+// File returns the name of the source that defines the definition.
+func (o *Origin) File() string {
+	if loc := o.Definition.GetLoc(); loc != nil && loc.Source != nil {
+		return loc.Source.Name
+	}
+	return ""
 }
 
 func NewDocument(loc *Location, definitions []Node) *Document {
@@ -34,85 +55,123 @@ func NewDocument(loc *Location, definitions []Node) *Document {
 }
 
 func (d *Document) Accept(context Context, visitor Visitor) {
-	visitor.VisitDocumentBefore(context)
-
-	if context.Namespace != nil {
-		context.Namespace.Accept(context, visitor)
+	context.visit(visitor.VisitDocumentBefore)
+	if !context.descend() {
+		context.visitAfter(visitor.VisitDocumentAfter)
+		return
 	}
 
-	visitor.VisitImportsBefore(context)
-	for _, importDef := range context.Imports {
-		c := context
-		c.Import = importDef
-		importDef.Accept(c, visitor)
+	if context.Namespace != nil && context.visits(context.Namespace) {
+		context.Namespace.Accept(context, visitor)
 	}
-	visitor.VisitImportsAfter(context)
 
-	visitor.VisitDirectivesBefore(context)
-	for _, directive := range context.Directives {
-		c := context
-		c.Directive = directive
-		directive.Accept(c, visitor)
+	context.visit(visitor.VisitImportsBefore)
+	if context.descend() {
+		for _, importDef := range context.Imports {
+			if !context.visits(importDef) {
+				continue
+			}
+			c := context
+			c.Import = importDef
+			importDef.Accept(c, visitor)
+		}
 	}
-	visitor.VisitDirectivesAfter(context)
-
-	visitor.VisitAliasesBefore(context)
-	for _, alias := range context.Aliases {
-		c := context
-		c.Alias = alias
-		alias.Accept(c, visitor)
+	context.visitAfter(visitor.VisitImportsAfter)
+
+	context.visit(visitor.VisitDirectivesBefore)
+	if context.descend() {
+		for _, directive := range context.Directives {
+			if !context.visits(directive) {
+				continue
+			}
+			c := context
+			c.Directive = directive
+			directive.Accept(c, visitor)
+		}
 	}
-	visitor.VisitAliasesAfter(context)
-
-	visitor.VisitAllOperationsBefore(context)
-
-	visitor.VisitFunctionsBefore(context)
-	for _, function := range context.Functions {
-		c := context
-		c.Function = function
-		function.Accept(c, visitor)
+	context.visitAfter(visitor.VisitDirectivesAfter)
+
+	context.visit(visitor.VisitAliasesBefore)
+	if context.descend() {
+		for _, alias := range context.Aliases {
+			if !context.visits(alias) {
+				continue
+			}
+			c := context
+			c.Alias = alias
+			alias.Accept(c, visitor)
+		}
 	}
-	visitor.VisitFunctionsAfter(context)
-
-	visitor.VisitInterfacesBefore(context)
-	for _, iface := range context.Interfaces {
-		c := context
-		c.Interface = iface
-		iface.Accept(c, visitor)
+	context.visitAfter(visitor.VisitAliasesAfter)
+
+	context.visit(visitor.VisitAllOperationsBefore)
+	if context.descend() {
+		context.visit(visitor.VisitFunctionsBefore)
+		if context.descend() {
+			for _, function := range context.Functions {
+				if !context.visits(function) {
+					continue
+				}
+				c := context
+				c.Function = function
+				function.Accept(c, visitor)
+			}
+		}
+		context.visitAfter(visitor.VisitFunctionsAfter)
+
+		context.visit(visitor.VisitInterfacesBefore)
+		if context.descend() {
+			for _, iface := range context.Interfaces {
+				if !context.visits(iface) {
+					continue
+				}
+				c := context
+				c.Interface = iface
+				iface.Accept(c, visitor)
+			}
+		}
+		context.visitAfter(visitor.VisitInterfacesAfter)
 	}
-	visitor.VisitInterfacesAfter(context)
-
-	visitor.VisitAllOperationsAfter(context)
-
-	visitor.VisitTypesBefore(context)
-	for _, t := range context.Types {
-		if t.Annotation("novisit") != nil {
-			continue
+	context.visitAfter(visitor.VisitAllOperationsAfter)
+
+	context.visit(visitor.VisitTypesBefore)
+	if context.descend() {
+		for _, t := range context.Types {
+			if !context.visits(t) {
+				continue
+			}
+			c := context
+			c.Type = t
+			t.Accept(c, visitor)
 		}
-		c := context
-		c.Type = t
-		t.Accept(c, visitor)
 	}
-	visitor.VisitTypesAfter(context)
-
-	visitor.VisitUnionsBefore(context)
-	for _, union := range context.Unions {
-		c := context
-		c.Union = union
-		union.Accept(c, visitor)
+	context.visitAfter(visitor.VisitTypesAfter)
+
+	context.visit(visitor.VisitUnionsBefore)
+	if context.descend() {
+		for _, union := range context.Unions {
+			if !context.visits(union) {
+				continue
+			}
+			c := context
+			c.Union = union
+			union.Accept(c, visitor)
+		}
 	}
-	visitor.VisitUnionsAfter(context)
-
-	visitor.VisitEnumsBefore(context)
-	for _, enumDef := range context.Enums {
-		if enumDef.Annotation("novisit") != nil {
-			continue
+	context.visitAfter(visitor.VisitUnionsAfter)
+
+	context.visit(visitor.VisitEnumsBefore)
+	if context.descend() {
+		for _, enumDef := range context.Enums {
+			if !context.visits(enumDef) {
+				continue
+			}
+			c := context
+			c.Enum = enumDef
+			enumDef.Accept(c, visitor)
 		}
-		c := context
-		c.Enum = enumDef
-		enumDef.Accept(c, visitor)
 	}
-	visitor.VisitEnumsAfter(context)
+	context.visitAfter(visitor.VisitEnumsAfter)
 
-	visitor.VisitDocumentAfter(context)
+	context.visitAfter(visitor.VisitDocumentAfter)
 }
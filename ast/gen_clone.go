@@ -0,0 +1,211 @@
+//go:build ignore
+
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// gen_clone generates the Clone and Equal methods of the node types in
+// clone_generated.go. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// manual are node types whose methods are written by hand in clone.go,
+// while skipped types are embedded or not nodes.
+var (
+	manual  = map[string]bool{"Document": true}
+	skipped = map[string]bool{"BaseNode": true, "AnnotatedNode": true, "ValuedDefinition": true}
+
+	// interfaces are the interface types of node fields.
+	interfaces = map[string]bool{"Node": true, "Type": true, "Value": true}
+)
+
+type field struct {
+	name string
+	typ  string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") &&
+			fi.Name() != "clone_generated.go" && fi.Name() != "gen_clone.go"
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	structs := map[string]*ast.StructType{}
+	underlying := map[string]string{}
+	for _, file := range pkgs["ast"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				switch t := ts.Type.(type) {
+				case *ast.StructType:
+					structs[ts.Name.Name] = t
+				case *ast.Ident:
+					underlying[ts.Name.Name] = t.Name
+				}
+			}
+		}
+	}
+
+	fieldsOf := func(name string) []field {
+		var fields []field
+		var walk func(st *ast.StructType)
+		walk = func(st *ast.StructType) {
+			for _, f := range st.Fields.List {
+				typ := typeString(f.Type)
+				if len(f.Names) == 0 {
+					if typ == "BaseNode" {
+						fields = append(fields, field{"Kind", "kinds.Kind"}, field{"Loc", "*Location"})
+					} else {
+						walk(structs[typ])
+					}
+					continue
+				}
+				for _, n := range f.Names {
+					fields = append(fields, field{n.Name, typ})
+				}
+			}
+		}
+		walk(structs[name])
+		return fields
+	}
+
+	isNode := func(name string) bool {
+		st, ok := structs[name]
+		if !ok {
+			return false
+		}
+		for _, f := range st.Fields.List {
+			if len(f.Names) == 0 && typeString(f.Type) == "BaseNode" {
+				return true
+			}
+		}
+		return false
+	}
+
+	nodes := map[string]string{}
+	for name := range structs {
+		if isNode(name) && !skipped[name] {
+			nodes[name] = name
+		}
+	}
+	for name, u := range underlying {
+		if isNode(u) {
+			nodes[name] = u
+		}
+	}
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_clone.go. DO NOT EDIT.\n\npackage ast\n\n")
+	b.WriteString("// Clone returns a deep copy of n. Locations are copied while their\n// sources are shared.\n")
+	b.WriteString("func Clone(n Node) Node {\n\tswitch n := n.(type) {\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\tcase *%s:\n\t\treturn n.Clone()\n", name)
+	}
+	b.WriteString("\t}\n\treturn n\n}\n")
+
+	for _, name := range names {
+		if manual[name] {
+			continue
+		}
+		fields := fieldsOf(nodes[name])
+
+		fmt.Fprintf(&b, "\n// Clone returns a deep copy of n.\nfunc (n *%s) Clone() *%s {\n", name, name)
+		fmt.Fprintf(&b, "\tif n == nil {\n\t\treturn nil\n\t}\n\tc := *n\n")
+		for _, f := range fields {
+			switch {
+			case strings.HasPrefix(f.typ, "*"):
+				fmt.Fprintf(&b, "\tc.%s = n.%s.Clone()\n", f.name, f.name)
+			case strings.HasPrefix(f.typ, "[]"):
+				fmt.Fprintf(&b, "\tc.%s = cloneList(n.%s)\n", f.name, f.name)
+			case interfaces[f.typ]:
+				fmt.Fprintf(&b, "\tc.%s = cloneAs(n.%s)\n", f.name, f.name)
+			}
+		}
+		b.WriteString("\treturn &c\n}\n")
+
+		fmt.Fprintf(&b, "\n// Equal returns true if other is a %s with the same fields as n,\n// comparing locations unless ignoreLocations is set.\n", name)
+		fmt.Fprintf(&b, "func (n *%s) Equal(other Node, ignoreLocations bool) bool {\n", name)
+		fmt.Fprintf(&b, "\to, ok := other.(*%s)\n\tif !ok || n == nil || o == nil {\n\t\treturn ok && n == o\n\t}\n\treturn ", name)
+		for i, f := range fields {
+			if i > 0 {
+				b.WriteString(" &&\n\t\t")
+			}
+			switch {
+			case f.typ == "*Location":
+				fmt.Fprintf(&b, "(ignoreLocations || n.%s.Equal(o.%s))", f.name, f.name)
+			case strings.HasPrefix(f.typ, "*"):
+				fmt.Fprintf(&b, "n.%s.Equal(o.%s, ignoreLocations)", f.name, f.name)
+			case strings.HasPrefix(f.typ, "[]"):
+				fmt.Fprintf(&b, "equalList(n.%s, o.%s, ignoreLocations)", f.name, f.name)
+			case interfaces[f.typ]:
+				fmt.Fprintf(&b, "equalAs(n.%s, o.%s, ignoreLocations)", f.name, f.name)
+			default:
+				fmt.Fprintf(&b, "n.%s == o.%s", f.name, f.name)
+			}
+		}
+		b.WriteString("\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("%v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile("clone_generated.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// typeString returns the source of a field type.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	}
+	log.Fatalf("unsupported field type %T", expr)
+	return ""
+}
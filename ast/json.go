@@ -0,0 +1,527 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apexlang/apex-go/kinds"
+)
+
+// JSONVersion is the version of the JSON representation of documents.
+// It changes when a document in the current representation could no
+// longer be read back.
+const JSONVersion = 1
+
+// In JSON, every node has a "kind" taken from kinds.Kind, which selects
+// the node type of definitions, types and values. Locations are left
+// out, so nodes read back from JSON have none.
+
+var (
+	definitionKinds = map[kinds.Kind]func() Node{
+		kinds.NamespaceDefinition: func() Node { return &NamespaceDefinition{} },
+		kinds.ImportDefinition:    func() Node { return &ImportDefinition{} },
+		kinds.AliasDefinition:     func() Node { return &AliasDefinition{} },
+		kinds.InterfaceDefinition: func() Node { return &InterfaceDefinition{} },
+		kinds.OperationDefinition: func() Node { return &OperationDefinition{} },
+		kinds.TypeDefinition:      func() Node { return &TypeDefinition{} },
+		kinds.UnionDefinition:     func() Node { return &UnionDefinition{} },
+		kinds.EnumDefinition:      func() Node { return &EnumDefinition{} },
+		kinds.DirectiveDefinition: func() Node { return &DirectiveDefinition{} },
+	}
+	typeKinds = map[kinds.Kind]func() Node{
+		kinds.Named:    func() Node { return &Named{} },
+		kinds.ListType: func() Node { return &ListType{} },
+		kinds.MapType:  func() Node { return &MapType{} },
+		kinds.Optional: func() Node { return &Optional{} },
+		kinds.Stream:   func() Node { return &Stream{} },
+	}
+	valueKinds = map[kinds.Kind]func() Node{
+		kinds.IntValue:     func() Node { return &IntValue{} },
+		kinds.FloatValue:   func() Node { return &FloatValue{} },
+		kinds.StringValue:  func() Node { return &StringValue{} },
+		kinds.BooleanValue: func() Node { return &BooleanValue{} },
+		kinds.EnumValue:    func() Node { return &EnumValue{} },
+		kinds.ListValue:    func() Node { return &ListValue{} },
+		kinds.ObjectValue:  func() Node { return &ObjectValue{} },
+	}
+)
+
+// unmarshalNode decodes the node in data with the type that types maps
+// its kind to. It returns nil for null.
+func unmarshalNode(data json.RawMessage, what string, types map[kinds.Kind]func() Node) (Node, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var base BaseNode
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, err
+	}
+	newNode, ok := types[base.Kind]
+	if !ok {
+		return nil, fmt.Errorf("ast: unknown %s kind %q", what, base.Kind)
+	}
+	n := newNode()
+	if err := json.Unmarshal(data, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func unmarshalType(data json.RawMessage) (Type, error) {
+	return unmarshalNode(data, "type", typeKinds)
+}
+
+func unmarshalValue(data json.RawMessage) (Value, error) {
+	n, err := unmarshalNode(data, "value", valueKinds)
+	if n == nil || err != nil {
+		return nil, err
+	}
+	return n.(Value), nil
+}
+
+func unmarshalList[T Node](data []json.RawMessage, unmarshal func(json.RawMessage) (T, error)) ([]T, error) {
+	if data == nil {
+		return nil, nil
+	}
+	list := make([]T, len(data))
+	for i, item := range data {
+		n, err := unmarshal(item)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = n
+	}
+	return list, nil
+}
+
+// MarshalJSON encodes d with the JSONVersion.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	type plain Document
+	return json.Marshal(struct {
+		*plain
+		Version int `json:"version"`
+	}{(*plain)(d), JSONVersion})
+}
+
+// UnmarshalJSON decodes a document encoded by MarshalJSON, failing for
+// versions newer than JSONVersion and for nodes missing a required child.
+// A missing version is read as 1.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	type plain Document
+	var v struct {
+		*plain
+		Version     int               `json:"version"`
+		Definitions []json.RawMessage `json:"definitions"`
+	}
+	v.plain = (*plain)(d)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Version > JSONVersion {
+		return fmt.Errorf("ast: unsupported document version %d, want at most %d", v.Version, JSONVersion)
+	}
+	definitions, err := unmarshalList(v.Definitions, func(data json.RawMessage) (Node, error) {
+		return unmarshalNode(data, "definition", definitionKinds)
+	})
+	d.Definitions = definitions
+	if err != nil {
+		return err
+	}
+	return checkRequired(d)
+}
+
+// checkRequired reports the first node below root that lacks a child the
+// parser always sets, such as a name or a type. Rules and conversion
+// rely on those children, so such documents are rejected when decoded.
+func checkRequired(root Node) (err error) {
+	Inspect(root, func(n Node) bool {
+		if n == nil || err != nil {
+			return false
+		}
+		if missing := missingChild(n); missing != "" {
+			err = fmt.Errorf("ast: %s has no %s", n.GetKind(), missing)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// missingChild returns the name of the first required child of n that
+// is nil, including nil elements of lists, or "" if there is none.
+func missingChild(n Node) string {
+	switch n := n.(type) {
+	case *Document:
+		if hasNil(n.Definitions) {
+			return "definitions"
+		}
+	case *NamespaceDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Annotations):
+			return "annotations"
+		}
+	case *ImportDefinition:
+		switch {
+		case hasNil(n.Names):
+			return "names"
+		case n.From == nil:
+			return "from"
+		case hasNil(n.Annotations):
+			return "annotations"
+		}
+	case *DirectiveDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Parameters):
+			return "parameters"
+		case hasNil(n.Locations):
+			return "locations"
+		case hasNil(n.Requires):
+			return "requires"
+		}
+	case *AliasDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case n.Type == nil:
+			return "type"
+		case hasNil(n.Annotations):
+			return "annotations"
+		}
+	case *TypeDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Interfaces):
+			return "interfaces"
+		case hasNil(n.Annotations):
+			return "annotations"
+		case hasNil(n.Fields):
+			return "fields"
+		}
+	case *FieldDefinition:
+		return (*ValuedDefinition)(n).missingChild()
+	case *ParameterDefinition:
+		return (*ValuedDefinition)(n).missingChild()
+	case *InterfaceDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Annotations):
+			return "annotations"
+		case hasNil(n.Operations):
+			return "operations"
+		}
+	case *OperationDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Parameters):
+			return "parameters"
+		case n.Type == nil:
+			return "type"
+		case hasNil(n.Annotations):
+			return "annotations"
+		}
+	case *UnionDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Annotations):
+			return "annotations"
+		case hasNil(n.Types):
+			return "types"
+		}
+	case *EnumDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Annotations):
+			return "annotations"
+		case hasNil(n.Values):
+			return "values"
+		}
+	case *EnumValueDefinition:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case n.Index == nil:
+			return "index"
+		case hasNil(n.Annotations):
+			return "annotations"
+		}
+	case *Annotation:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case hasNil(n.Arguments):
+			return "arguments"
+		}
+	case *Argument:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case n.Value == nil:
+			return "value"
+		}
+	case *DirectiveRequire:
+		switch {
+		case n.Directive == nil:
+			return "directive"
+		case hasNil(n.Locations):
+			return "locations"
+		}
+	case *ImportName:
+		if n.Name == nil {
+			return "name"
+		}
+	case *Named:
+		if n.Name == nil {
+			return "name"
+		}
+	case *ListType:
+		if n.Type == nil {
+			return "type"
+		}
+	case *Optional:
+		if n.Type == nil {
+			return "type"
+		}
+	case *Stream:
+		if n.Type == nil {
+			return "type"
+		}
+	case *MapType:
+		switch {
+		case n.KeyType == nil:
+			return "keyType"
+		case n.ValueType == nil:
+			return "valueType"
+		}
+	case *ListValue:
+		if hasNil(n.Values) {
+			return "values"
+		}
+	case *ObjectValue:
+		if hasNil(n.Fields) {
+			return "fields"
+		}
+	case *ObjectField:
+		switch {
+		case n.Name == nil:
+			return "name"
+		case n.Value == nil:
+			return "value"
+		}
+	}
+	return ""
+}
+
+func (n *ValuedDefinition) missingChild() string {
+	switch {
+	case n.Name == nil:
+		return "name"
+	case n.Type == nil:
+		return "type"
+	case hasNil(n.Annotations):
+		return "annotations"
+	}
+	return ""
+}
+
+func hasNil[T Node](list []T) bool {
+	for _, n := range list {
+		if isNil(n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *AliasDefinition) UnmarshalJSON(data []byte) (err error) {
+	type plain AliasDefinition
+	var v struct {
+		*plain
+		Type json.RawMessage `json:"type"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Type, err = unmarshalType(v.Type)
+	return err
+}
+
+func (n *OperationDefinition) UnmarshalJSON(data []byte) (err error) {
+	type plain OperationDefinition
+	var v struct {
+		*plain
+		Type json.RawMessage `json:"type"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Type, err = unmarshalType(v.Type)
+	return err
+}
+
+func (n *FieldDefinition) UnmarshalJSON(data []byte) error {
+	return (*ValuedDefinition)(n).unmarshalJSON(data)
+}
+
+func (n *ParameterDefinition) UnmarshalJSON(data []byte) error {
+	return (*ValuedDefinition)(n).unmarshalJSON(data)
+}
+
+func (n *ValuedDefinition) unmarshalJSON(data []byte) (err error) {
+	type plain ValuedDefinition
+	var v struct {
+		*plain
+		Type    json.RawMessage `json:"type"`
+		Default json.RawMessage `json:"default"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if n.Type, err = unmarshalType(v.Type); err != nil {
+		return err
+	}
+	n.Default, err = unmarshalValue(v.Default)
+	return err
+}
+
+func (n *UnionDefinition) UnmarshalJSON(data []byte) (err error) {
+	type plain UnionDefinition
+	var v struct {
+		*plain
+		Types []json.RawMessage `json:"types"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Types, err = unmarshalList(v.Types, unmarshalType)
+	return err
+}
+
+func (n *ListType) UnmarshalJSON(data []byte) (err error) {
+	type plain ListType
+	var v struct {
+		*plain
+		Type json.RawMessage `json:"type"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Type, err = unmarshalType(v.Type)
+	return err
+}
+
+func (n *MapType) UnmarshalJSON(data []byte) (err error) {
+	type plain MapType
+	var v struct {
+		*plain
+		KeyType   json.RawMessage `json:"keyType"`
+		ValueType json.RawMessage `json:"valueType"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if n.KeyType, err = unmarshalType(v.KeyType); err != nil {
+		return err
+	}
+	n.ValueType, err = unmarshalType(v.ValueType)
+	return err
+}
+
+func (n *Optional) UnmarshalJSON(data []byte) (err error) {
+	type plain Optional
+	var v struct {
+		*plain
+		Type json.RawMessage `json:"type"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Type, err = unmarshalType(v.Type)
+	return err
+}
+
+func (n *Stream) UnmarshalJSON(data []byte) (err error) {
+	type plain Stream
+	var v struct {
+		*plain
+		Type json.RawMessage `json:"type"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Type, err = unmarshalType(v.Type)
+	return err
+}
+
+func (n *Argument) UnmarshalJSON(data []byte) (err error) {
+	type plain Argument
+	var v struct {
+		*plain
+		Value json.RawMessage `json:"value"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Value, err = unmarshalValue(v.Value)
+	return err
+}
+
+func (n *ObjectField) UnmarshalJSON(data []byte) (err error) {
+	type plain ObjectField
+	var v struct {
+		*plain
+		Value json.RawMessage `json:"value"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Value, err = unmarshalValue(v.Value)
+	return err
+}
+
+func (n *ListValue) UnmarshalJSON(data []byte) (err error) {
+	type plain ListValue
+	var v struct {
+		*plain
+		Values []json.RawMessage `json:"values"`
+	}
+	v.plain = (*plain)(n)
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Values, err = unmarshalList(v.Values, unmarshalValue)
+	return err
+}
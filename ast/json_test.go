@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	body, err := os.ReadFile("../model.axdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := parse(string(body) + `
+union Value = string | [i64] | {string: f64} | Value?
+
+type Defaults @config(names: ["a", "b"], limits: {min: 1, max: 2.5}, kind: LARGE) {
+  name: string = "x"
+  count: i32 = 2
+  enabled: bool = true
+}
+`)
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ast.Document
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !doc.Equal(&decoded, true) {
+		t.Error("decoded document is not equal to the original")
+	}
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Error("decoded document encodes differently")
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	tests := []struct {
+		json string
+		err  string
+	}{
+		{`{"kind":"Document","version":2,"definitions":[]}`, "unsupported document version 2"},
+		{`{"kind":"Document","definitions":[{"kind":"Named"}]}`, `unknown definition kind "Named"`},
+		{`{"kind":"Document","definitions":[{"kind":"AliasDefinition","type":{"kind":"StringValue"}}]}`, `unknown type kind "StringValue"`},
+		{`{"kind":"Document","definitions":[{"kind":"TypeDefinition","fields":[{"kind":"FieldDefinition","default":{}}]}]}`, `unknown value kind ""`},
+		{`{"kind":"Document","definitions":[null]}`, "Document has no definitions"},
+		{`{"kind":"Document","definitions":[{"kind":"NamespaceDefinition"}]}`, "NamespaceDefinition has no name"},
+		{`{"kind":"Document","definitions":[{"kind":"TypeDefinition"}]}`, "TypeDefinition has no name"},
+		{`{"kind":"Document","definitions":[{"kind":"AliasDefinition","name":{"kind":"Name","value":"A"},"type":{"kind":"Named"}}]}`, "Named has no name"},
+		{`{"kind":"Document","definitions":[{"kind":"AliasDefinition","name":{"kind":"Name","value":"A"}}]}`, "AliasDefinition has no type"},
+		{`{"kind":"Document","definitions":[{"kind":"TypeDefinition","name":{"kind":"Name","value":"T"},"fields":[{"kind":"FieldDefinition","name":{"kind":"Name","value":"f"}}]}]}`, "FieldDefinition has no type"},
+		{`{"kind":"Document","definitions":[{"kind":"TypeDefinition","name":{"kind":"Name","value":"T"},"fields":[null]}]}`, "TypeDefinition has no fields"},
+		{`{"kind":"Document","definitions":[{"kind":"EnumDefinition","name":{"kind":"Name","value":"E"},"values":[{"kind":"EnumValueDefinition","name":{"kind":"Name","value":"A"}}]}]}`, "EnumValueDefinition has no index"},
+		{`{"kind":"Document","definitions":[{"kind":"UnionDefinition","name":{"kind":"Name","value":"U"},"types":[null]}]}`, "UnionDefinition has no types"},
+		{`{"kind":"Document","definitions":[{"kind":"AliasDefinition","name":{"kind":"Name","value":"A"},"type":{"kind":"MapType","keyType":{"kind":"Named","name":{"kind":"Name","value":"string"}}}}]}`, "MapType has no valueType"},
+	}
+	for _, tt := range tests {
+		var doc ast.Document
+		err := json.Unmarshal([]byte(tt.json), &doc)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Unmarshal(%s) = %v, want %q", tt.json, err, tt.err)
+		}
+	}
+}
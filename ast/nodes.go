@@ -79,7 +79,7 @@ func NewAnnotation(loc *Location, name *Name, arguments []*Argument) *Annotation
 }
 
 func (a *Annotation) Accept(context Context, visitor Visitor) {
-	visitor.VisitAnnotation(context)
+	context.visit(visitor.VisitAnnotation)
 }
 
 // Argument implements Node
@@ -110,7 +110,7 @@ type DirectiveRequire struct {
 
 func NewDirectiveRequire(loc *Location, directive *Name, locations []*Name) *DirectiveRequire {
 	return &DirectiveRequire{
-		BaseNode:  BaseNode{kinds.Argument, loc},
+		BaseNode:  BaseNode{kinds.DirectiveRequire, loc},
 		Directive: directive,
 		Locations: locations,
 	}
@@ -0,0 +1,336 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "fmt"
+
+// RewriteFunc is called by Rewrite for each node. Its result controls
+// the traversal, see Rewrite.
+type RewriteFunc func(c *Cursor) bool
+
+// Rewrite traverses root and all nodes below it in source order, calling
+// pre before and post after the children of each node. Either may be
+// nil. Nil nodes, such as a missing description, are not visited.
+//
+// If pre returns false or deletes the node, the children of the node and
+// post are skipped. If post returns false, the traversal stops.
+//
+// Both may change the tree through the Cursor. Nodes inserted before the
+// current node are not visited, while nodes inserted after it and nodes
+// replacing it are. Rewrite returns root, or its replacement.
+func Rewrite(root Node, pre, post RewriteFunc) Node {
+	a := &rewriter{pre: pre, post: post}
+	a.apply(nil, "", field[Node]{&root}, nil, root)
+	return root
+}
+
+// Inspect traverses node and all nodes below it in source order. It
+// calls f for each node and, if f returns true, for the children of the
+// node followed by f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Rewrite(node, func(c *Cursor) bool {
+		return f(c.Node())
+	}, func(*Cursor) bool {
+		f(nil)
+		return true
+	})
+}
+
+// Cursor describes the node being visited by Rewrite and the field of
+// its parent that holds it.
+type Cursor struct {
+	parent Node
+	name   string
+	slot   slot
+	iter   *iterator
+	node   Node
+}
+
+// Node returns the current node.
+func (c *Cursor) Node() Node { return c.node }
+
+// Parent returns the node that holds the current node, or nil for the
+// root.
+func (c *Cursor) Parent() Node { return c.parent }
+
+// Name returns the name of the field of Parent holding the current node,
+// such as "Fields" or "Description".
+func (c *Cursor) Name() string { return c.name }
+
+// Index returns the index of the current node in the slice holding it,
+// or -1 if it is not held by a slice.
+func (c *Cursor) Index() int {
+	if c.iter == nil {
+		return -1
+	}
+	return c.iter.index
+}
+
+// Replace replaces the current node with n. It panics if n cannot be
+// stored in the field holding the current node.
+func (c *Cursor) Replace(n Node) {
+	c.slot.set(c.Index(), n)
+	c.node = n
+}
+
+// Delete removes the current node from the slice holding it, or clears
+// the field holding it.
+func (c *Cursor) Delete() {
+	if c.iter == nil {
+		c.slot.set(-1, nil)
+		c.node = nil
+		return
+	}
+	c.slot.(sequence).delete(c.iter.index)
+	c.iter.step--
+	c.node = nil
+}
+
+// InsertBefore inserts n before the current node in the slice holding
+// it. It panics if the current node is not held by a slice. Rewrite does
+// not visit n.
+func (c *Cursor) InsertBefore(n Node) {
+	if c.iter == nil {
+		panic("ast: InsertBefore on a node that is not in a slice")
+	}
+	c.slot.(sequence).insert(c.iter.index, n)
+	c.iter.index++
+}
+
+// InsertAfter inserts n after the current node in the slice holding it.
+// It panics if the current node is not held by a slice. Rewrite visits
+// n after the current node.
+func (c *Cursor) InsertAfter(n Node) {
+	if c.iter == nil {
+		panic("ast: InsertAfter on a node that is not in a slice")
+	}
+	c.slot.(sequence).insert(c.iter.index+1, n)
+}
+
+// iterator tracks the position of Rewrite in a slice as the cursor
+// edits it.
+type iterator struct {
+	index int
+	step  int
+}
+
+// slot is the field of a parent node holding the current node.
+type slot interface {
+	set(i int, n Node)
+}
+
+// sequence is a slot holding a slice of nodes.
+type sequence interface {
+	slot
+	delete(i int)
+	insert(i int, n Node)
+}
+
+type field[T Node] struct {
+	ptr *T
+}
+
+func (f field[T]) set(_ int, n Node) { *f.ptr = as[T](n) }
+
+type list[T Node] struct {
+	ptr *[]T
+}
+
+func (l list[T]) set(i int, n Node) { (*l.ptr)[i] = as[T](n) }
+
+func (l list[T]) delete(i int) {
+	s := *l.ptr
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	*l.ptr = s[:len(s)-1]
+}
+
+func (l list[T]) insert(i int, n Node) {
+	var zero T
+	s := append(*l.ptr, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = as[T](n)
+	*l.ptr = s
+}
+
+// as converts n to the type of the field it is stored in.
+func as[T Node](n Node) T {
+	var zero T
+	if n == nil {
+		return zero
+	}
+	t, ok := n.(T)
+	if !ok {
+		panic(fmt.Sprintf("ast: cannot store %T in a field of type %T", n, zero))
+	}
+	return t
+}
+
+func isNil[T Node](n T) bool {
+	var zero T
+	return Node(n) == Node(zero)
+}
+
+type rewriter struct {
+	pre, post RewriteFunc
+	cursor    Cursor
+	iter      iterator
+	stopped   bool
+}
+
+func (a *rewriter) apply(parent Node, name string, s slot, iter *iterator, n Node) {
+	saved := a.cursor
+	a.cursor = Cursor{parent: parent, name: name, slot: s, iter: iter, node: n}
+	defer func() { a.cursor = saved }()
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		return
+	}
+	if a.cursor.node == nil {
+		return
+	}
+	a.children(a.cursor.node)
+	if a.stopped {
+		return
+	}
+	if a.post != nil && !a.post(&a.cursor) {
+		a.stopped = true
+	}
+}
+
+func applyField[T Node](a *rewriter, parent Node, name string, ptr *T) {
+	if a.stopped || isNil(*ptr) {
+		return
+	}
+	a.apply(parent, name, field[T]{ptr}, nil, *ptr)
+}
+
+func applyList[T Node](a *rewriter, parent Node, name string, ptr *[]T) {
+	saved := a.iter
+	a.iter.index = 0
+	for a.iter.index < len(*ptr) && !a.stopped {
+		a.iter.step = 1
+		if n := (*ptr)[a.iter.index]; !isNil(n) {
+			a.apply(parent, name, list[T]{ptr}, &a.iter, n)
+		}
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
+
+func (a *rewriter) children(n Node) {
+	switch n := n.(type) {
+	case *Document:
+		applyList(a, n, "Definitions", &n.Definitions)
+	case *NamespaceDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *ImportDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyList(a, n, "Names", &n.Names)
+		applyField(a, n, "From", &n.From)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *DirectiveDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Parameters", &n.Parameters)
+		applyList(a, n, "Locations", &n.Locations)
+		applyList(a, n, "Requires", &n.Requires)
+	case *AliasDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Type", &n.Type)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *TypeDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Interfaces", &n.Interfaces)
+		applyList(a, n, "Annotations", &n.Annotations)
+		applyList(a, n, "Fields", &n.Fields)
+	case *FieldDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Type", &n.Type)
+		applyField(a, n, "Default", &n.Default)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *InterfaceDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Annotations", &n.Annotations)
+		applyList(a, n, "Operations", &n.Operations)
+	case *OperationDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Parameters", &n.Parameters)
+		applyField(a, n, "Type", &n.Type)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *ParameterDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Type", &n.Type)
+		applyField(a, n, "Default", &n.Default)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *UnionDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Annotations", &n.Annotations)
+		applyList(a, n, "Types", &n.Types)
+	case *EnumDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Annotations", &n.Annotations)
+		applyList(a, n, "Values", &n.Values)
+	case *EnumValueDefinition:
+		applyField(a, n, "Description", &n.Description)
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Index", &n.Index)
+		applyField(a, n, "Display", &n.Display)
+		applyList(a, n, "Annotations", &n.Annotations)
+	case *Annotation:
+		applyField(a, n, "Name", &n.Name)
+		applyList(a, n, "Arguments", &n.Arguments)
+	case *Argument:
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Value", &n.Value)
+	case *DirectiveRequire:
+		applyField(a, n, "Directive", &n.Directive)
+		applyList(a, n, "Locations", &n.Locations)
+	case *ImportName:
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Alias", &n.Alias)
+	case *Named:
+		applyField(a, n, "Name", &n.Name)
+	case *ListType:
+		applyField(a, n, "Type", &n.Type)
+	case *MapType:
+		applyField(a, n, "KeyType", &n.KeyType)
+		applyField(a, n, "ValueType", &n.ValueType)
+	case *Optional:
+		applyField(a, n, "Type", &n.Type)
+	case *Stream:
+		applyField(a, n, "Type", &n.Type)
+	case *ListValue:
+		applyList(a, n, "Values", &n.Values)
+	case *ObjectValue:
+		applyList(a, n, "Fields", &n.Fields)
+	case *ObjectField:
+		applyField(a, n, "Name", &n.Name)
+		applyField(a, n, "Value", &n.Value)
+	}
+}
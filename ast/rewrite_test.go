@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"os"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/printer"
+)
+
+const spec = `namespace "greeting"
+
+interface Greeter {
+  hello(userId: string): string
+  goodbye(userId: string): string
+}
+
+type User {
+  userId: string
+  name: string
+}
+`
+
+func parse(src string) *ast.Document {
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		panic(err)
+	}
+	return doc
+}
+
+func ExampleRewrite() {
+	doc := parse(spec)
+
+	// Rename userId to id everywhere and deprecate the operations of
+	// Greeter.
+	ast.Rewrite(doc, func(c *ast.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.Name:
+			if n.Value == "userId" {
+				c.Replace(ast.Ident("id"))
+			}
+		case *ast.InterfaceDefinition:
+			if n.Name.Value == "Greeter" {
+				for _, op := range n.Operations {
+					op.AddAnnotation(ast.Annotate("deprecated", ast.Arg("reason", "use Welcomer")))
+				}
+			}
+		}
+		return true
+	}, nil)
+
+	printer.Fprint(os.Stdout, doc)
+	// Output:
+	// namespace "greeting"
+	//
+	// interface Greeter {
+	//   hello(id: string): string @deprecated(reason: "use Welcomer")
+	//   goodbye(id: string): string @deprecated(reason: "use Welcomer")
+	// }
+	//
+	// type User {
+	//   id: string
+	//   name: string
+	// }
+}
+
+func ExampleCursor_InsertAfter() {
+	doc := parse(spec)
+
+	// Add a field after each name field and drop the goodbye operation.
+	ast.Rewrite(doc, func(c *ast.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.FieldDefinition:
+			if n.Name.Value == "name" {
+				c.InsertAfter(ast.Field("email", ast.OptionalOf(ast.TypeName("string")), ast.Annotate("email")))
+			}
+		case *ast.OperationDefinition:
+			if n.Name.Value == "goodbye" {
+				c.Delete()
+			}
+		}
+		return true
+	}, nil)
+
+	printer.Fprint(os.Stdout, doc)
+	// Output:
+	// namespace "greeting"
+	//
+	// interface Greeter {
+	//   hello(userId: string): string
+	// }
+	//
+	// type User {
+	//   userId: string
+	//   name: string
+	//   email: string? @email
+	// }
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+// Symbol is a named definition of a document and the nodes referring
+// to it.
+type Symbol struct {
+	Name       string
+	Definition Definition
+	// Origin is where an imported definition was defined, or nil if it
+	// is defined in the document.
+	Origin *Origin
+	// References are the nodes referring to the symbol in document
+	// order: *Named types for types, *Annotation nodes and the
+	// directive *Name of requires for directives.
+	References []Node
+}
+
+// File returns the name of the source that defines the symbol.
+func (s *Symbol) File() string {
+	if s.Origin != nil {
+		return s.Origin.File()
+	}
+	if loc := s.Definition.GetLoc(); loc != nil && loc.Source != nil {
+		return loc.Source.Name
+	}
+	return ""
+}
+
+// SymbolTable binds the references of a document to the definitions
+// they refer to. Types, aliases, unions, enums and interfaces share one
+// scope, while directives and functions have their own. When a name is
+// defined twice, the first definition wins.
+type SymbolTable struct {
+	symbols    []*Symbol
+	types      map[string]*Symbol
+	directives map[string]*Symbol
+	functions  map[string]*Symbol
+	defined    map[Node]*Symbol
+	bindings   map[Node]*Symbol
+	unresolved []Node
+}
+
+// NewSymbolTable resolves the references of doc.
+func NewSymbolTable(doc *Document) *SymbolTable {
+	t := &SymbolTable{
+		types:      make(map[string]*Symbol),
+		directives: make(map[string]*Symbol),
+		functions:  make(map[string]*Symbol),
+		defined:    make(map[Node]*Symbol),
+		bindings:   make(map[Node]*Symbol),
+	}
+	for _, node := range doc.Definitions {
+		switch def := node.(type) {
+		case *TypeDefinition:
+			t.define(t.types, def.Name, def, doc)
+		case *AliasDefinition:
+			t.define(t.types, def.Name, def, doc)
+		case *UnionDefinition:
+			t.define(t.types, def.Name, def, doc)
+		case *EnumDefinition:
+			t.define(t.types, def.Name, def, doc)
+		case *InterfaceDefinition:
+			t.define(t.types, def.Name, def, doc)
+		case *DirectiveDefinition:
+			t.define(t.directives, def.Name, def, doc)
+		case *OperationDefinition:
+			t.define(t.functions, def.Name, def, doc)
+		}
+	}
+
+	Inspect(doc, func(n Node) bool {
+		switch n := n.(type) {
+		case *Named:
+			t.bind(n, t.types[n.Name.Value])
+		case *Annotation:
+			t.bind(n, t.directives[n.Name.Value])
+		case *DirectiveRequire:
+			t.bind(n.Directive, t.directives[n.Directive.Value])
+		case *ImportDefinition:
+			// Imported names refer to the imported document.
+			return false
+		}
+		return true
+	})
+	return t
+}
+
+func (t *SymbolTable) define(scope map[string]*Symbol, name *Name, def Definition, doc *Document) {
+	if name == nil {
+		return
+	}
+	if _, exists := scope[name.Value]; exists {
+		return
+	}
+	s := &Symbol{
+		Name:       name.Value,
+		Definition: def,
+		Origin:     doc.Origins[def],
+	}
+	scope[name.Value] = s
+	t.defined[def] = s
+	t.symbols = append(t.symbols, s)
+}
+
+func (t *SymbolTable) bind(ref Node, s *Symbol) {
+	if s == nil {
+		t.unresolved = append(t.unresolved, ref)
+		return
+	}
+	t.bindings[ref] = s
+	s.References = append(s.References, ref)
+}
+
+// Symbols returns all symbols in document order.
+func (t *SymbolTable) Symbols() []*Symbol {
+	return t.symbols
+}
+
+// Type returns the type, alias, union, enum or interface named name.
+func (t *SymbolTable) Type(name string) *Symbol {
+	return t.types[name]
+}
+
+// Directive returns the directive named name.
+func (t *SymbolTable) Directive(name string) *Symbol {
+	return t.directives[name]
+}
+
+// Function returns the function named name.
+func (t *SymbolTable) Function(name string) *Symbol {
+	return t.functions[name]
+}
+
+// Lookup returns the symbol defined by def, or nil.
+func (t *SymbolTable) Lookup(def Definition) *Symbol {
+	return t.defined[def]
+}
+
+// Resolve returns the symbol a reference refers to, or nil. References
+// are *Named types, *Annotation nodes and the directive *Name of
+// requires.
+func (t *SymbolTable) Resolve(ref Node) *Symbol {
+	return t.bindings[ref]
+}
+
+// Unresolved returns the references that refer to no definition in
+// document order, such as built-in types and annotations without a
+// directive.
+func (t *SymbolTable) Unresolved() []Node {
+	return t.unresolved
+}
+
+// Unused returns the symbols without references, leaving out functions
+// and interfaces, which are the entry points of a spec.
+func (t *SymbolTable) Unused() []*Symbol {
+	var unused []*Symbol
+	for _, s := range t.symbols {
+		switch s.Definition.(type) {
+		case *OperationDefinition, *InterfaceDefinition:
+			continue
+		}
+		if len(s.References) == 0 {
+			unused = append(unused, s)
+		}
+	}
+	return unused
+}
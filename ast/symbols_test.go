@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestSymbolTable(t *testing.T) {
+	files := map[string]string{
+		"common.apex": `namespace "common"
+
+import * from "ids.apex"
+
+type Page {
+  cursor: ID
+}
+
+directive @range(min: i32) on FIELD
+`,
+		"ids.apex": `namespace "ids"
+
+alias ID = string
+`,
+	}
+	spec := `namespace "users"
+
+import { Page as UserPage, ID, range } from "common.apex"
+
+interface Users {
+  list(page: UserPage): [User]
+}
+
+type User {
+  id: ID
+  age: u8 @range(min: 0) @deprecated
+}
+
+type Unused {
+  id: ID
+}
+`
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource("users.apex", []byte(spec)),
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return files[location], nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := ast.NewSymbolTable(doc)
+
+	symbolFiles := map[string]string{}
+	for _, s := range table.Symbols() {
+		symbolFiles[s.Name] = s.File()
+	}
+	wantFiles := map[string]string{
+		"UserPage": "common.apex",
+		"ID":       "ids.apex",
+		"Users":    "users.apex",
+		"User":     "users.apex",
+		"Unused":   "users.apex",
+		"range":    "common.apex",
+	}
+	if !reflect.DeepEqual(symbolFiles, wantFiles) {
+		t.Errorf("files %v, want %v", symbolFiles, wantFiles)
+	}
+
+	id := table.Type("ID")
+	if id == nil || len(id.References) != 3 {
+		t.Fatalf("ID has references %v, want 3", id)
+	}
+	if s := table.Resolve(id.References[0]); s != id {
+		t.Errorf("Resolve(%v) = %v, want ID", id.References[0], s)
+	}
+	if o := table.Type("UserPage").Origin; o == nil || o.Import == nil || o.Import.From.Value != "common.apex" {
+		t.Errorf("UserPage has origin %+v", o)
+	}
+	if refs := table.Directive("range").References; len(refs) != 1 {
+		t.Errorf("range has references %v, want 1", refs)
+	}
+
+	var unresolved []string
+	for _, ref := range table.Unresolved() {
+		switch ref := ref.(type) {
+		case *ast.Named:
+			unresolved = append(unresolved, ref.Name.Value)
+		case *ast.Annotation:
+			unresolved = append(unresolved, "@"+ref.Name.Value)
+		}
+	}
+	if want := []string{"string", "i32", "u8", "@deprecated"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("unresolved %v, want %v", unresolved, want)
+	}
+
+	var unused []string
+	for _, s := range table.Unused() {
+		unused = append(unused, s.Name)
+	}
+	if want := []string{"Unused"}; !reflect.DeepEqual(unused, want) {
+		t.Errorf("unused %v, want %v", unused, want)
+	}
+}
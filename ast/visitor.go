@@ -49,11 +49,49 @@ type Context struct {
 
 	Named map[string]Definition
 
+	// Skip, if set, returns true for definitions that Accept should not
+	// visit, along with everything below them. NewContext sets it to
+	// NoVisit.
+	Skip SkipFunc
+
 	persistent *contextPersistent
 }
 
 type contextPersistent struct {
-	Errors []error
+	Errors  []error
+	skip    bool
+	stopped bool
+}
+
+// SkipFunc reports whether to skip visiting a definition.
+type SkipFunc func(def Definition) bool
+
+// NoVisit skips types and enums annotated with @novisit.
+func NoVisit(def Definition) bool {
+	switch d := def.(type) {
+	case *TypeDefinition:
+		return d.Annotation("novisit") != nil
+	case *EnumDefinition:
+		return d.Annotation("novisit") != nil
+	}
+	return false
+}
+
+// SkipAnnotated returns a SkipFunc that skips definitions with any of
+// the annotations.
+func SkipAnnotated(annotations ...string) SkipFunc {
+	return func(def Definition) bool {
+		annotated, ok := def.(interface{ Annotation(string) *Annotation })
+		if !ok {
+			return false
+		}
+		for _, name := range annotations {
+			if annotated.Annotation(name) != nil {
+				return true
+			}
+		}
+		return false
+	}
 }
 
 func NewContext(doc *Document) Context {
@@ -61,6 +99,7 @@ func NewContext(doc *Document) Context {
 	c := Context{
 		Document:   doc,
 		Named:      named,
+		Skip:       NoVisit,
 		persistent: &contextPersistent{},
 	}
 	for _, def := range doc.Definitions {
@@ -100,6 +139,58 @@ func (c *Context) Errors() []error {
 	return c.persistent.Errors
 }
 
+// SkipChildren skips the nodes below the one being visited, such as the
+// fields of a type or the annotations of an operation. Called from a
+// method visiting a list, such as VisitTypesBefore, it skips the list.
+// The matching After methods are still called. It has no effect in
+// After methods.
+func (c *Context) SkipChildren() {
+	c.persistent.skip = true
+}
+
+// Stop ends the traversal. No visitor methods are called after the
+// current one returns.
+func (c *Context) Stop() {
+	c.persistent.stopped = true
+}
+
+// Stopped returns true once Stop has been called.
+func (c *Context) Stopped() bool {
+	return c.persistent != nil && c.persistent.stopped
+}
+
+// visit calls f unless the traversal has stopped.
+func (c *Context) visit(f func(Context)) {
+	if !c.Stopped() {
+		f(*c)
+	}
+}
+
+// visitAfter is visit for After methods, after which requests to skip
+// children are dropped.
+func (c *Context) visitAfter(f func(Context)) {
+	c.visit(f)
+	if c.persistent != nil {
+		c.persistent.skip = false
+	}
+}
+
+// descend returns true if the children of the node just visited should
+// be visited, consuming a request to skip them.
+func (c *Context) descend() bool {
+	if c.persistent == nil {
+		return true
+	}
+	skip := c.persistent.skip
+	c.persistent.skip = false
+	return !skip && !c.persistent.stopped
+}
+
+// visits returns true if def should be visited.
+func (c *Context) visits(def Definition) bool {
+	return !c.Stopped() && (c.Skip == nil || !c.Skip(def))
+}
+
 type Visitor interface {
 	VisitDocumentBefore(context Context)
 	VisitNamespace(context Context)
@@ -449,7 +540,7 @@ func (m *MultiVisitor) VisitUnionsBefore(context Context) {
 	m.visit(func(v Visitor) { v.VisitUnionsBefore(context) })
 }
 func (m *MultiVisitor) VisitUnion(context Context) {
-	m.visit(func(v Visitor) { v.VisitUnionsBefore(context) })
+	m.visit(func(v Visitor) { v.VisitUnion(context) })
 }
 func (m *MultiVisitor) VisitUnionsAfter(context Context) {
 	m.visit(func(v Visitor) { v.VisitUnionsAfter(context) })
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+const visited = `namespace "visited"
+
+type A @novisit {
+  a: string
+}
+
+type B {
+  b: string
+  c: string
+}
+
+type C @internal {
+  d: string
+}
+
+enum E {
+  one = 1
+}
+`
+
+// recorder records the names of the types, fields and enums it visits
+// and calls control on each.
+type recorder struct {
+	ast.BaseVisitor
+	names   []string
+	control func(context ast.Context, name string)
+}
+
+func (r *recorder) record(context ast.Context, name string) {
+	r.names = append(r.names, name)
+	if r.control != nil {
+		r.control(context, name)
+	}
+}
+
+func (r *recorder) VisitType(context ast.Context) {
+	r.record(context, context.Type.Name.Value)
+}
+
+func (r *recorder) VisitTypeField(context ast.Context) {
+	r.record(context, context.Type.Name.Value+"."+context.Field.Name.Value)
+}
+
+func (r *recorder) VisitEnum(context ast.Context) {
+	r.record(context, context.Enum.Name.Value)
+}
+
+func TestVisitorControl(t *testing.T) {
+	tests := []struct {
+		name    string
+		skip    ast.SkipFunc
+		control func(context ast.Context, name string)
+		want    []string
+	}{
+		{
+			name: "novisit",
+			skip: ast.NoVisit,
+			want: []string{"B", "B.b", "B.c", "C", "C.d", "E"},
+		},
+		{
+			name: "all",
+			want: []string{"A", "A.a", "B", "B.b", "B.c", "C", "C.d", "E"},
+		},
+		{
+			name: "annotated",
+			skip: ast.SkipAnnotated("novisit", "internal"),
+			want: []string{"B", "B.b", "B.c", "E"},
+		},
+		{
+			name: "skip children",
+			skip: ast.NoVisit,
+			control: func(context ast.Context, name string) {
+				if name == "B" {
+					context.SkipChildren()
+				}
+			},
+			want: []string{"B", "C", "C.d", "E"},
+		},
+		{
+			name: "stop",
+			skip: ast.NoVisit,
+			control: func(context ast.Context, name string) {
+				if name == "B.b" {
+					context.Stop()
+				}
+			},
+			want: []string{"B", "B.b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parse(visited)
+			context := ast.NewContext(doc)
+			context.Skip = tt.skip
+			r := &recorder{control: tt.control}
+			doc.Accept(context, r)
+			if !reflect.DeepEqual(r.names, tt.want) {
+				t.Errorf("visited %v, want %v", r.names, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleInspect() {
+	doc := parse(visited)
+
+	// Print the fields of B without descending into other types.
+	ast.Inspect(doc, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeDefinition:
+			return n.Name.Value == "B"
+		case *ast.FieldDefinition:
+			fmt.Println(n.Name.Value)
+			return false
+		}
+		return true
+	})
+	// Output:
+	// b
+	// c
+}
@@ -17,14 +17,12 @@ limitations under the License.
 package main
 
 import (
-	"fmt"
+	"runtime"
+	"unsafe"
 
 	"github.com/tetratelabs/tinymem"
 
-	"github.com/apexlang/apex-go/errors"
-	"github.com/apexlang/apex-go/model"
-	"github.com/apexlang/apex-go/parser"
-	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/abi"
 )
 
 // main is required for TinyGo to compile to Wasm.
@@ -36,50 +34,36 @@ func resolve(
 	locationPtr uintptr, locationSize uint32,
 	fromPtr uintptr, fromSize uint32) uint64
 
+// response keeps the last parse response alive until the host has
+// read it.
+var response []byte
+
 //export parse
 func Parse(ptr uintptr, size uint32) (ptrSize uint64) {
 	source := tinymem.PtrToString(ptr, size)
 
-	doc, err := parser.Parse(parser.ParseParams{
-		Source: source,
-		Options: parser.ParseOptions{
-			NoSource: true,
-			Resolver: func(location, from string) (string, error) {
-				locationPtr, locationSize := tinymem.StringToPtr(location)
-				fromPtr, fromSize := tinymem.StringToPtr(from)
-				ptrsize := resolve(
-					locationPtr, locationSize,
-					fromPtr, fromSize)
-				if ptrsize == 0 {
-					return "", fmt.Errorf("could not find %q", location)
-				}
-
-				ptr := uintptr(ptrsize >> 32)
-				size := uint32(ptrsize & 0xFFFFFFFF)
-				return tinymem.PtrToString(ptr, size), nil
-			},
-		},
+	response = abi.Parse(source, func(location, from string) (string, error) {
+		locationBytes, fromBytes := []byte(location), []byte(from)
+		ptrSize := resolve(
+			bytesToPtr(locationBytes), uint32(len(locationBytes)),
+			bytesToPtr(fromBytes), uint32(len(fromBytes)))
+		runtime.KeepAlive(locationBytes)
+		runtime.KeepAlive(fromBytes)
+
+		// The host frees the response once parse returns, so the
+		// source is copied.
+		return abi.DecodeResolve(ptrSize, func(ptr, size uint32) ([]byte, bool) {
+			return []byte(tinymem.PtrToString(uintptr(ptr), size)), true
+		})
 	})
-	if err != nil {
-		return errors.Return(err)
-	}
-
-	errs := rules.Validate(doc, rules.Rules...)
-	if len(errs) > 0 {
-		return errors.Return(errs...)
-	}
 
-	ns, errs := model.Convert(doc)
-	if len(errs) > 0 {
-		return errors.Return(errs...)
-	}
+	return abi.Pack(uint32(bytesToPtr(response)), uint32(len(response)))
+}
 
-	jsonBytes, err := ns.MarshalJSON()
-	if err != nil {
-		return errors.Return(err)
+// bytesToPtr returns the address of b, or zero if it is empty.
+func bytesToPtr(b []byte) uintptr {
+	if len(b) == 0 {
+		return 0
 	}
-
-	jsonString := string(jsonBytes)
-	ptr, size = tinymem.StringToPtr(jsonString)
-	return (uint64(ptr) << uint64(32)) | uint64(size)
+	return uintptr(unsafe.Pointer(&b[0]))
 }
@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wapc/tinygo-msgpack"
+
+	"github.com/apexlang/apex-go/lexer"
+	"github.com/apexlang/apex-go/location"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/source"
+)
+
+func parse(e *env, args []string) int {
+	o := newOptions(e, "parse")
+	if code, ok := o.parse(args); !ok {
+		return code
+	}
+	ws, code := o.load(true)
+	if ws == nil {
+		return code
+	}
+	return o.report(ws)
+}
+
+func validate(e *env, args []string) int {
+	o := newOptions(e, "validate")
+	if code, ok := o.parse(args); !ok {
+		return code
+	}
+	ws, code := o.load(false)
+	if ws == nil {
+		return code
+	}
+	return o.report(ws)
+}
+
+func convert(e *env, args []string) int {
+	o := newOptions(e, "convert")
+	format := o.flags.String("format", "json", "output format: json, msgpack or yaml")
+	if code, ok := o.parse(args); !ok {
+		return code
+	}
+	switch *format {
+	case "json", "msgpack", "yaml":
+	default:
+		fmt.Fprintf(e.stderr, "unknown output format %q\n", *format)
+		return exitUsage
+	}
+
+	ws, code := o.load(false)
+	if ws == nil {
+		return code
+	}
+	if code := o.report(ws); code != exitOK {
+		return code
+	}
+
+	namespaces := ws.Namespaces()
+	var out []byte
+	var err error
+	switch *format {
+	case "json":
+		out, err = marshalJSON(namespaces)
+	case "yaml":
+		out, err = marshalYAML(namespaces)
+	case "msgpack":
+		out, err = marshalMsgpack(namespaces)
+	}
+	if err != nil {
+		fmt.Fprintln(e.stderr, err)
+		return exitIO
+	}
+	return e.write(out)
+}
+
+// marshalJSON encodes a single namespace as an object and several as
+// an array.
+func marshalJSON(namespaces []*model.Namespace) ([]byte, error) {
+	if len(namespaces) == 1 {
+		return namespaces[0].MarshalJSON()
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, ns := range namespaces {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		jsonBytes, err := ns.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(jsonBytes)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// marshalYAML encodes each namespace as a YAML document.
+func marshalYAML(namespaces []*model.Namespace) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, ns := range namespaces {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		jsonBytes, err := ns.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if err := jsonToYAML(&buf, jsonBytes); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalMsgpack encodes the namespaces one after the other.
+func marshalMsgpack(namespaces []*model.Namespace) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, ns := range namespaces {
+		msgpackBytes, err := msgpack.ToBytes(ns)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(msgpackBytes)
+	}
+	return buf.Bytes(), nil
+}
+
+func printAST(e *env, args []string) int {
+	o := newOptions(e, "ast")
+	if code, ok := o.parse(args); !ok {
+		return code
+	}
+	ws, code := o.load(true)
+	if ws == nil {
+		return code
+	}
+	if code := o.report(ws); code != exitOK {
+		return code
+	}
+
+	var value interface{}
+	if len(ws.Files) == 1 {
+		value = ws.Files[0].Document
+	} else {
+		docs := make([]interface{}, len(ws.Files))
+		for i, file := range ws.Files {
+			docs[i] = file.Document
+		}
+		value = docs
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		fmt.Fprintln(e.stderr, err)
+		return exitIO
+	}
+	return e.write(out)
+}
+
+func tokens(e *env, args []string) int {
+	o := newOptions(e, "tokens")
+	if code, ok := o.parse(args); !ok {
+		return code
+	}
+	ws, code := o.load(true)
+	if ws == nil {
+		return code
+	}
+
+	var buf bytes.Buffer
+	for _, file := range ws.Files {
+		if len(ws.Files) > 1 {
+			fmt.Fprintf(&buf, "%s:\n", file.Path)
+		}
+		if err := writeTokens(&buf, file.Source); err != nil {
+			// The error is reported as a diagnostic of the file.
+			break
+		}
+	}
+	if code := e.write(buf.Bytes()); code != exitOK {
+		return code
+	}
+	return o.report(ws)
+}
+
+// writeTokens writes one line per token of src with its position, kind
+// and value.
+func writeTokens(buf *bytes.Buffer, src *source.Source) error {
+	lex := lexer.Lex(src)
+	for {
+		token, err := lex.Next()
+		if err != nil {
+			return err
+		}
+		l := location.GetLocation(src, token.Start)
+		fmt.Fprintf(buf, "%d:%d\t%s", l.Line, l.Column, lexer.GetTokenKindDesc(token.Kind))
+		if token.Value != "" {
+			fmt.Fprintf(buf, "\t%q", token.Value)
+		}
+		buf.WriteByte('\n')
+		if token.Kind == lexer.EOF {
+			return nil
+		}
+	}
+}
+
+func (e *env) write(out []byte) int {
+	if _, err := e.stdout.Write(out); err != nil {
+		fmt.Fprintln(e.stderr, err)
+		return exitIO
+	}
+	return exitOK
+}
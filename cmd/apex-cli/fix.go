@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+// maxFixPasses bounds the number of times a file is revalidated to
+// pick up fixes that overlapped with ones applied in a previous pass.
+const maxFixPasses = 10
+
+// fix applies the edits attached to validation errors to each file
+// given in args and returns the process exit code.
+func fix(e *env, args []string) int {
+	flags := flag.NewFlagSet("fix", flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	dryRun := flags.Bool("dry-run", false, "write the fixed spec to stdout instead of the file")
+	var targets targetList
+	flags.Var(&targets, "targets", targetsUsage)
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprintln(e.stderr, "usage: apex-cli fix [-dry-run] [-targets list] <file>...")
+		return exitUsage
+	}
+
+	code := exitOK
+	for _, filename := range flags.Args() {
+		body, err := os.ReadFile(filename)
+		if err != nil {
+			fmt.Fprintln(e.stderr, err)
+			code = exitIO
+			continue
+		}
+
+		fixed, applied, err := fixSource(filename, body, rules.Profile{Targets: targets}.Rules())
+		if err != nil {
+			fmt.Fprintln(e.stderr, err)
+			if code == exitOK {
+				code = exitDiagnostics
+			}
+			continue
+		}
+
+		if *dryRun {
+			e.stdout.Write(fixed)
+			continue
+		}
+		if applied == 0 {
+			continue
+		}
+		if err := writeFileAtomic(filename, fixed); err != nil {
+			fmt.Fprintln(e.stderr, err)
+			code = exitIO
+			continue
+		}
+		fmt.Fprintf(e.stderr, "%s: applied %d fixes\n", filename, applied)
+	}
+
+	return code
+}
+
+// fixSource repeatedly validates body with validationRules and applies
+// the fixes that do not overlap each other until no fixes remain. The result is parsed
+// again before it is returned so a fix can never produce a spec that
+// does not parse.
+func fixSource(filename string, body []byte, validationRules []rules.ValidationRule) ([]byte, int, error) {
+	applied := 0
+	for pass := 0; pass < maxFixPasses; pass++ {
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(filename, body),
+		})
+		if err != nil {
+			if applied > 0 {
+				return nil, 0, fmt.Errorf("%s: fixes produced an invalid spec: %w", filename, err)
+			}
+			return nil, 0, err
+		}
+
+		var edits []source.TextEdit
+		count := 0
+		for _, err := range rules.Validate(doc, validationRules...) {
+			e, ok := err.(*errors.Error)
+			if !ok || len(e.Edits) == 0 || source.Overlaps(edits, e.Edits) {
+				continue
+			}
+			edits = append(edits, e.Edits...)
+			count++
+		}
+		if count == 0 {
+			break
+		}
+
+		if body, err = source.ApplyEdits(body, edits); err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", filename, err)
+		}
+		applied += count
+	}
+
+	if applied > 0 {
+		if _, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(filename, body),
+		}); err != nil {
+			return nil, 0, fmt.Errorf("%s: fixes produced an invalid spec: %w", filename, err)
+		}
+	}
+
+	return body, applied, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to filename and
+// renames it into place so an interrupted write never truncates the
+// original.
+func writeFileAtomic(filename string, data []byte) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,42 @@
+//go:build !wasi && !wasip1
+
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// runGenerator runs command with the generator configuration appended
+// as its last argument, from the directory of the configuration, and
+// writes its output to output.
+func runGenerator(command, config string, output io.Writer) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return errors.New("empty generator command")
+	}
+	cmd := exec.Command(args[0], append(args[1:], config)...)
+	cmd.Dir = filepath.Dir(config)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	return cmd.Run()
+}
@@ -17,50 +17,261 @@ limitations under the License.
 package main
 
 import (
+	"flag"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/apexlang/apex-go/errors"
-	"github.com/apexlang/apex-go/model"
 	"github.com/apexlang/apex-go/parser"
 	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+	"github.com/apexlang/apex-go/workspace"
 )
 
+// Exit codes
+const (
+	exitOK          = 0
+	exitDiagnostics = 1
+	exitUsage       = 2
+	exitIO          = 3
+)
+
+// stdinName is the name given to a spec read from stdin.
+const stdinName = "<stdin>"
+
+type command struct {
+	name    string
+	summary string
+	run     func(e *env, args []string) int
+}
+
+// env holds the standard streams of a run so that commands can be
+// run from tests.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"parse", "check that specs parse", parse},
+		{"validate", "parse and validate specs", validate},
+		{"convert", "convert specs to their model as json, msgpack or yaml", convert},
+		{"ast", "print the syntax tree of specs as json", printAST},
+		{"tokens", "print the tokens of specs", tokens},
+		{"fix", "apply the fixes suggested by validation rules", fix},
+		{"watch", "validate specs and run generators whenever they change", watch},
+	}
+}
+
 func main() {
-	specBytes, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		errors.Write(err)
-		return
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command line args and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	// Without a command, convert the spec read from stdin to JSON and
+	// report diagnostics as JSON like earlier versions did.
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "-help" {
+		return convert(e, append([]string{"-diagnostics", "json"}, args...))
 	}
 
-	doc, err := parser.Parse(parser.ParseParams{
-		Source: string(specBytes),
-		Options: parser.ParseOptions{
-			NoSource: true,
-		},
-	})
-	if err != nil {
-		errors.Write(err)
-		return
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(e, args[1:])
+		}
+	}
+
+	usage(e.stderr)
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		return exitOK
+	}
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: apex-cli <command> [flags] [file|dir|glob ...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Specs are read from stdin if no files are given.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// options are the flags shared by the commands that load specs.
+type options struct {
+	env         *env
+	flags       *flag.FlagSet
+	roots       stringList
+	diagnostics string
+	name        string
+	targets     targetList
+
+	// resolver replaces the resolver for the definition roots.
+	resolver    parser.Resolver
+	importCache *parser.ImportCache
+}
+
+func newOptions(e *env, name string) *options {
+	o := options{
+		env:   e,
+		flags: flag.NewFlagSet(name, flag.ContinueOnError),
+	}
+	o.flags.SetOutput(e.stderr)
+	o.flags.Var(&o.roots, "I", "definition root to resolve imports from (repeatable)")
+	o.flags.StringVar(&o.diagnostics, "diagnostics", "human",
+		"diagnostics format: "+strings.Join(errors.FormatterNames(), ", "))
+	o.flags.StringVar(&o.name, "name", stdinName, "name of the spec read from stdin")
+	o.flags.Var(&o.targets, "targets", targetsUsage)
+	return &o
+}
+
+// parse parses the command line and returns the exit code to stop
+// with if it is invalid.
+func (o *options) parse(args []string) (int, bool) {
+	if err := o.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK, false
+		}
+		return exitUsage, false
 	}
+	if _, ok := errors.LookupFormatter(o.diagnostics); !ok {
+		fmt.Fprintf(o.env.stderr, "unknown diagnostics format %q\n", o.diagnostics)
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+// definitionRoots returns the roots given with -I followed by the
+// definitions directory of APEX_HOME, which defaults to ~/.apex.
+func (o *options) definitionRoots() []string {
+	roots := append([]string{}, o.roots...)
+	home := os.Getenv("APEX_HOME")
+	if home == "" {
+		if userHome := os.Getenv("HOME"); userHome != "" {
+			home = filepath.Join(userHome, ".apex")
+		}
+	}
+	if home != "" {
+		roots = append(roots, filepath.Join(home, "definitions"))
+	}
+	return roots
+}
 
-	errs := rules.Validate(doc, rules.Rules...)
-	if len(errs) > 0 {
-		errors.Write(errs...)
-		return
+// load loads the specs given as arguments, or the spec on stdin if
+// there are none.
+func (o *options) load(parseOnly bool) (*workspace.Workspace, int) {
+	wsOptions := workspace.Options{
+		Resolver:    o.resolver,
+		Rules:       rules.Profile{Targets: o.targets}.Rules(),
+		ParseOnly:   parseOnly,
+		ImportCache: o.importCache,
+	}
+	if wsOptions.Resolver == nil {
+		wsOptions.Resolver = workspace.DirResolver(o.definitionRoots()...)
 	}
 
-	ns, errs := model.Convert(doc)
-	if len(errs) > 0 {
-		errors.Write(errs...)
-		return
+	args := o.flags.Args()
+	if len(args) == 0 || len(args) == 1 && args[0] == "-" {
+		body, err := io.ReadAll(o.env.stdin)
+		if err != nil {
+			fmt.Fprintln(o.env.stderr, err)
+			return nil, exitIO
+		}
+		return workspace.New([]*source.Source{source.NewSource(o.name, body)}, wsOptions), exitOK
 	}
 
-	jsonBytes, err := ns.MarshalJSON()
+	ws, err := workspace.Load(args, wsOptions)
 	if err != nil {
-		errors.Write(err)
-		return
+		fmt.Fprintln(o.env.stderr, err)
+		return nil, exitIO
+	}
+	return ws, exitOK
+}
+
+// report writes the diagnostics of ws to stderr and returns the exit
+// code for them.
+func (o *options) report(ws *workspace.Workspace) int {
+	if !ws.HasErrors() {
+		return exitOK
+	}
+	formatter, _ := errors.LookupFormatter(o.diagnostics)
+	if h, ok := formatter.(errors.Human); ok && (os.Getenv("NO_COLOR") != "" || !isTerminal(o.env.stderr)) {
+		h.Color = false
+		formatter = h
+	}
+	if err := formatter.Format(o.env.stderr, errors.Convert(ws.Errors()...)); err != nil {
+		fmt.Fprintln(o.env.stderr, err)
+		return exitIO
+	}
+	return exitDiagnostics
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
 	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stringList is a flag that can be given multiple times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// targetsUsage is the usage of the -targets flag.
+var targetsUsage = "comma-separated languages whose reserved words cannot be used as names: " +
+	strings.Join(targetKeys(), ", ")
+
+// targetList is a flag holding a comma-separated list of the keys of
+// rules.Targets.
+type targetList []rules.Target
 
-	os.Stdout.Write(jsonBytes)
+func (l *targetList) String() string {
+	keys := make([]string, len(*l))
+	for i, target := range *l {
+		keys[i] = target.Key
+	}
+	return strings.Join(keys, ",")
+}
+
+func (l *targetList) Set(value string) error {
+	*l = nil
+	for _, key := range strings.Split(value, ",") {
+		target, ok := rules.Targets[strings.TrimSpace(key)]
+		if !ok {
+			return fmt.Errorf("unknown target %q, expected one of %s", key, strings.Join(targetKeys(), ", "))
+		}
+		*l = append(*l, target)
+	}
+	return nil
+}
+
+func targetKeys() []string {
+	keys := make([]string, 0, len(rules.Targets))
+	for key := range rules.Targets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
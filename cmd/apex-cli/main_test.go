@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+const (
+	validSpec   = "namespace \"ns\"\n\ntype User { id: string }\n"
+	invalidSpec = "namespace \"ns\"\n\ntype user { id: string }\n"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		// files are written to a temporary directory that replaces
+		// {dir} in args and the expected output.
+		files  map[string]string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "help",
+			args:   []string{"help"},
+			code:   exitOK,
+			stderr: "usage: apex-cli <command>",
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"bogus"},
+			code:   exitUsage,
+			stderr: "usage: apex-cli <command>",
+		},
+		{
+			name:   "unknown flag",
+			args:   []string{"validate", "-bogus"},
+			code:   exitUsage,
+			stderr: "flag provided but not defined: -bogus",
+		},
+		{
+			name:   "unknown diagnostics format",
+			args:   []string{"validate", "-diagnostics", "bogus"},
+			code:   exitUsage,
+			stderr: `unknown diagnostics format "bogus"`,
+		},
+		{
+			name:   "unknown output format",
+			args:   []string{"convert", "-format", "bogus"},
+			code:   exitUsage,
+			stderr: `unknown output format "bogus"`,
+		},
+		{
+			name:  "valid file",
+			args:  []string{"validate", "{dir}/a.apex"},
+			files: map[string]string{"a.apex": validSpec},
+			code:  exitOK,
+		},
+		{
+			name:   "invalid file",
+			args:   []string{"validate", "{dir}/a.apex"},
+			files:  map[string]string{"a.apex": invalidSpec},
+			code:   exitDiagnostics,
+			stderr: "{dir}/a.apex:3:6: error: Validation Error: type \"user\" should be pascal case",
+		},
+		{
+			name:   "missing file",
+			args:   []string{"validate", "{dir}/missing.apex"},
+			code:   exitIO,
+			stderr: "{dir}/missing.apex: no such file or directory",
+		},
+		{
+			name:   "stdin",
+			args:   []string{"validate"},
+			stdin:  invalidSpec,
+			code:   exitDiagnostics,
+			stderr: "<stdin>:3:6: error:",
+		},
+		{
+			name:   "stdin dash",
+			args:   []string{"validate", "-name", "spec.apex", "-"},
+			stdin:  invalidSpec,
+			code:   exitDiagnostics,
+			stderr: "spec.apex:3:6: error:",
+		},
+		{
+			name:  "parse ignores validation",
+			args:  []string{"parse"},
+			stdin: invalidSpec,
+			code:  exitOK,
+		},
+		{
+			name:   "syntax error",
+			args:   []string{"parse"},
+			stdin:  "namespace",
+			code:   exitDiagnostics,
+			stderr: "<stdin>:1:10: error: Syntax Error",
+		},
+		{
+			name:   "github diagnostics",
+			args:   []string{"validate", "-diagnostics", "github"},
+			stdin:  invalidSpec,
+			code:   exitDiagnostics,
+			stderr: "::error file=<stdin>,line=3,col=6::",
+		},
+		{
+			name:   "json diagnostics",
+			args:   []string{"validate", "-diagnostics", "json"},
+			stdin:  invalidSpec,
+			code:   exitDiagnostics,
+			stderr: `"edits":[{"start":21,"end":25,"newText":"User"}]`,
+		},
+		{
+			name:   "default command",
+			stdin:  validSpec,
+			code:   exitOK,
+			stdout: `{"name":"ns","types":[{"name":"User",`,
+		},
+		{
+			name:   "default command diagnostics",
+			stdin:  invalidSpec,
+			code:   exitDiagnostics,
+			stderr: `[{"message":"Validation Error: type \"user\" should be pascal case"`,
+		},
+		{
+			name:   "yaml",
+			args:   []string{"convert", "-format", "yaml"},
+			stdin:  validSpec,
+			code:   exitOK,
+			stdout: "name: ns\n",
+		},
+		{
+			name:   "fix dry run",
+			args:   []string{"fix", "-dry-run", "{dir}/a.apex"},
+			files:  map[string]string{"a.apex": invalidSpec},
+			code:   exitOK,
+			stdout: validSpec,
+		},
+		{
+			name:   "fix",
+			args:   []string{"fix", "{dir}/a.apex"},
+			files:  map[string]string{"a.apex": invalidSpec},
+			code:   exitOK,
+			stderr: "{dir}/a.apex: applied 1 fixes",
+		},
+		{
+			name:   "fix without files",
+			args:   []string{"fix"},
+			code:   exitUsage,
+			stderr: "usage: apex-cli fix",
+		},
+		{
+			name:   "fix syntax error",
+			args:   []string{"fix", "{dir}/a.apex"},
+			files:  map[string]string{"a.apex": "namespace"},
+			code:   exitDiagnostics,
+			stderr: "Syntax Error",
+		},
+		{
+			name:   "fix missing file",
+			args:   []string{"fix", "{dir}/missing.apex"},
+			code:   exitIO,
+			stderr: "no such file or directory",
+		},
+		{
+			name:   "watch without files",
+			args:   []string{"watch"},
+			code:   exitUsage,
+			stderr: "usage: apex-cli watch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APEX_HOME", t.TempDir())
+			dir := filepath.ToSlash(t.TempDir())
+			for name, body := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			args := make([]string, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = strings.ReplaceAll(arg, "{dir}", dir)
+			}
+
+			var stdout, stderr bytes.Buffer
+			code := run(args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("got exit code %d, want %d\nstderr: %s", code, tt.code, stderr.String())
+			}
+			if want := strings.ReplaceAll(tt.stdout, "{dir}", dir); !strings.Contains(stdout.String(), want) {
+				t.Errorf("stdout %q does not contain %q", stdout.String(), want)
+			}
+			if want := strings.ReplaceAll(tt.stderr, "{dir}", dir); !strings.Contains(stderr.String(), want) {
+				t.Errorf("stderr %q does not contain %q", stderr.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunFixWritesFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.apex")
+	if err := os.WriteFile(filename, []byte(invalidSpec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"fix", filename}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+		t.Fatalf("got exit code %d, want %d\nstderr: %s", code, exitOK, stderr.String())
+	}
+	body, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != validSpec {
+		t.Errorf("got %q, want %q", body, validSpec)
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("got mode %v, want the original 0600", perm)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestRunIOErrors(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run([]string{"validate"}, errReader{}, io.Discard, &stderr); code != exitIO {
+		t.Errorf("reading stdin: got exit code %d, want %d", code, exitIO)
+	}
+	if code := run([]string{"convert"}, strings.NewReader(validSpec), errWriter{}, &stderr); code != exitIO {
+		t.Errorf("writing stdout: got exit code %d, want %d", code, exitIO)
+	}
+	if code := run([]string{"validate"}, strings.NewReader(invalidSpec), io.Discard, errWriter{}); code != exitIO {
+		t.Errorf("writing diagnostics: got exit code %d, want %d", code, exitIO)
+	}
+}
+
+// prependSpace always suggests a fix, so it never settles.
+func prependSpace() ast.Visitor { return &prependSpaceRule{} }
+
+type prependSpaceRule struct {
+	ast.BaseVisitor
+}
+
+func (r *prependSpaceRule) VisitNamespace(context ast.Context) {
+	err := rules.ValidationError(context.Namespace, "prepend a space")
+	err.Edits = []source.TextEdit{{Start: 0, End: 0, NewText: " "}}
+	context.ReportError(err)
+}
+
+func TestFixSourcePasses(t *testing.T) {
+	fixed, applied, err := fixSource("a.apex", []byte(validSpec), []rules.ValidationRule{prependSpace})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != maxFixPasses {
+		t.Errorf("applied %d fixes, want %d", applied, maxFixPasses)
+	}
+	if want := strings.Repeat(" ", maxFixPasses) + validSpec; string(fixed) != want {
+		t.Errorf("got %q, want %q", fixed, want)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/workspace"
+)
+
+// watch polls the specs, the files they import and the generator
+// configuration, and rebuilds whenever one of them changes. Polling
+// keeps it working under WASI where there are no file notifications.
+func watch(e *env, args []string) int {
+	o := newOptions(e, "watch")
+	interval := o.flags.Duration("interval", 500*time.Millisecond, "how often to check for changes")
+	debounce := o.flags.Duration("debounce", 200*time.Millisecond, "how long changes must settle before rebuilding")
+	config := o.flags.String("config", "apex.yaml", "generator configuration to watch")
+	generate := o.flags.String("generate", "apex generate",
+		"command run with the configuration after a successful build, empty to disable")
+	if code, ok := o.parse(args); !ok {
+		return code
+	}
+	if o.flags.NArg() == 0 {
+		fmt.Fprintln(e.stderr, "usage: apex-cli watch [flags] <file|dir|glob>...")
+		return exitUsage
+	}
+
+	w := newWatcher(o, *config, *generate)
+	var last map[string]fileStamp
+	for {
+		current := w.snapshot()
+		if last == nil || !sameStamps(last, current) {
+			// Wait for the changes to settle, editors often write a
+			// file in several steps.
+			for last != nil {
+				time.Sleep(*debounce)
+				next := w.snapshot()
+				if sameStamps(next, current) {
+					break
+				}
+				current = next
+			}
+			w.build()
+			// The build may have changed the set of imported files.
+			last = w.snapshot()
+		}
+		time.Sleep(*interval)
+	}
+}
+
+type watcher struct {
+	options  *options
+	config   string
+	generate string
+	roots    []string
+	// imports are the paths of the files imported by the last build.
+	imports map[string]struct{}
+}
+
+// newWatcher returns a watcher that loads the specs given by o, caching
+// their imports between builds.
+func newWatcher(o *options, config, generate string) *watcher {
+	w := &watcher{
+		options:  o,
+		config:   config,
+		generate: generate,
+		roots:    o.definitionRoots(),
+		imports:  map[string]struct{}{},
+	}
+	o.importCache = parser.NewImportCache()
+	o.resolver = w.resolve
+	return w
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// resolve resolves imports from the definition roots and records the
+// files they were read from so that they are watched too.
+func (w *watcher) resolve(location, from string) (string, error) {
+	path, err := workspace.LookupImport(location, from, w.roots...)
+	if err != nil {
+		return "", err
+	}
+	w.imports[path] = struct{}{}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// snapshot returns the stamps of all watched files. Missing files are
+// left out so that deleting a file counts as a change.
+func (w *watcher) snapshot() map[string]fileStamp {
+	paths, _ := workspace.Find(w.options.flags.Args()...)
+	paths = append(paths, w.config)
+	for path := range w.imports {
+		paths = append(paths, path)
+	}
+
+	stamps := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			stamps[path] = fileStamp{info.ModTime(), info.Size()}
+		}
+	}
+	return stamps
+}
+
+func sameStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		if other, ok := b[path]; !ok || !other.modTime.Equal(stamp.modTime) || other.size != stamp.size {
+			return false
+		}
+	}
+	return true
+}
+
+// build reloads and validates the specs and runs the generator if they
+// are valid.
+func (w *watcher) build() {
+	start := time.Now()
+	w.imports = map[string]struct{}{}
+
+	ws, _ := w.options.load(false)
+	if ws == nil {
+		return
+	}
+	if w.options.report(ws) != exitOK {
+		w.status("errors in %d of %d specs", countErrors(ws), len(ws.Files))
+		return
+	}
+	w.status("%d specs are valid (%s)", len(ws.Files), time.Since(start).Round(time.Millisecond))
+
+	if w.generate == "" {
+		return
+	}
+	if _, err := os.Stat(w.config); err != nil {
+		return
+	}
+	config, _ := filepath.Abs(w.config)
+	if err := runGenerator(w.generate, config, w.options.env.stderr); err != nil {
+		w.status("generator failed: %v", err)
+		return
+	}
+	w.status("generated from %s", w.config)
+}
+
+func (w *watcher) status(format string, a ...interface{}) {
+	fmt.Fprintf(w.options.env.stderr, "[%s] %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, a...))
+}
+
+func countErrors(ws *workspace.Workspace) int {
+	count := 0
+	for _, file := range ws.Files {
+		if len(file.Errors) > 0 {
+			count++
+		}
+	}
+	return count
+}
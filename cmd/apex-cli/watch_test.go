@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	specs := filepath.Join(dir, "specs")
+	root := filepath.Join(dir, "definitions")
+	common := filepath.Join(root, "common.apex")
+	config := filepath.Join(dir, "apex.yaml")
+	writeFile := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(specs, "a.apex"), "namespace \"a\"\nimport { Page } from \"common\"\ntype A { page: Page }\n")
+	writeFile(common, "namespace \"common\"\ntype Page { cursor: string }\n")
+
+	var stderr bytes.Buffer
+	o := newOptions(&env{stdout: &bytes.Buffer{}, stderr: &stderr}, "watch")
+	if _, ok := o.parse([]string{"-I", root, specs}); !ok {
+		t.Fatal(stderr.String())
+	}
+	w := newWatcher(o, config, "")
+	build := func(want string) {
+		t.Helper()
+		stderr.Reset()
+		w.build()
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("build wrote %q, want %q", stderr.String(), want)
+		}
+	}
+
+	build("1 specs are valid")
+	if _, ok := w.imports[common]; !ok {
+		t.Fatalf("imports %v do not include %s", w.imports, common)
+	}
+
+	// The imported file is watched, and changing it is picked up
+	// although the import is cached.
+	last := w.snapshot()
+	if _, ok := last[common]; !ok {
+		t.Fatalf("snapshot %v does not include %s", last, common)
+	}
+	writeFile(common, "namespace \"common\"\ntype Pages { cursor: string }\n")
+	if sameStamps(last, w.snapshot()) {
+		t.Error("changing an import is not a change")
+	}
+	build("errors in 1 of 1 specs")
+
+	writeFile(common, "namespace \"common\"\ntype Page { cursor: string }\n")
+	build("1 specs are valid")
+
+	// The generator only runs with a configuration.
+	w.generate = "echo running"
+	build("1 specs are valid")
+	if strings.Contains(stderr.String(), "running") {
+		t.Errorf("generator ran without a configuration: %q", stderr.String())
+	}
+	last = w.snapshot()
+	writeFile(config, "")
+	if sameStamps(last, w.snapshot()) {
+		t.Error("creating the configuration is not a change")
+	}
+	build("running " + config)
+	if !strings.Contains(stderr.String(), "generated from "+config) {
+		t.Errorf("build wrote %q, want the generator to succeed", stderr.String())
+	}
+
+	last = w.snapshot()
+	if err := os.Remove(config); err != nil {
+		t.Fatal(err)
+	}
+	if sameStamps(last, w.snapshot()) {
+		t.Error("deleting the configuration is not a change")
+	}
+}
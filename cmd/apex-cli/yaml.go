@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonToYAML converts a JSON document to block style YAML, keeping the
+// order of object keys. It only relies on the JSON tokenizer so it
+// builds with TinyGo.
+func jsonToYAML(buf *bytes.Buffer, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := readYAMLNode(dec)
+	if err != nil {
+		return err
+	}
+	if n.isBlock() {
+		writeYAMLBlock(buf, n, 0, false)
+	} else {
+		buf.WriteString(n.flow())
+		buf.WriteByte('\n')
+	}
+	return nil
+}
+
+type yamlKind int
+
+const (
+	yamlScalar yamlKind = iota
+	yamlObject
+	yamlArray
+)
+
+type yamlNode struct {
+	kind   yamlKind
+	keys   []string
+	values []*yamlNode
+	// scalar is the rendered value of a scalar node.
+	scalar string
+}
+
+func readYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		n := yamlNode{kind: yamlArray}
+		if t == '{' {
+			n.kind = yamlObject
+		}
+		for dec.More() {
+			if n.kind == yamlObject {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+			value, err := readYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, value)
+		}
+		// Consume the closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case string:
+		return &yamlNode{scalar: yamlString(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{scalar: fmt.Sprintf("%t", t)}, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", token)
+}
+
+func (n *yamlNode) isBlock() bool {
+	return n.kind != yamlScalar && len(n.values) > 0
+}
+
+// flow returns a scalar or an empty collection in flow style.
+func (n *yamlNode) flow() string {
+	switch n.kind {
+	case yamlObject:
+		return "{}"
+	case yamlArray:
+		return "[]"
+	}
+	return n.scalar
+}
+
+// writeYAMLBlock writes a non-empty object or array indented by indent
+// spaces. If inline is set, the first line follows a "- " that has
+// already been written.
+func writeYAMLBlock(buf *bytes.Buffer, n *yamlNode, indent int, inline bool) {
+	pad := strings.Repeat(" ", indent)
+	for i, value := range n.values {
+		if i > 0 || !inline {
+			buf.WriteString(pad)
+		}
+		if n.kind == yamlObject {
+			buf.WriteString(yamlString(n.keys[i]))
+			buf.WriteByte(':')
+			if value.isBlock() {
+				buf.WriteByte('\n')
+				writeYAMLBlock(buf, value, indent+2, false)
+				continue
+			}
+		} else {
+			buf.WriteByte('-')
+			if value.isBlock() {
+				buf.WriteByte(' ')
+				writeYAMLBlock(buf, value, indent+2, true)
+				continue
+			}
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(value.flow())
+		buf.WriteByte('\n')
+	}
+}
+
+var yamlReserved = map[string]struct{}{
+	"true": {}, "false": {}, "yes": {}, "no": {}, "on": {}, "off": {},
+	"y": {}, "n": {}, "null": {}, "~": {},
+}
+
+// yamlString returns s as a plain scalar if that is unambiguous and as
+// a double-quoted scalar otherwise.
+func yamlString(s string) string {
+	plain := s != ""
+	for i, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case i > 0 && (c >= '0' && c <= '9' || c == '.' || c == '-' || c == '/'):
+		default:
+			plain = false
+		}
+	}
+	if _, reserved := yamlReserved[strings.ToLower(s)]; reserved {
+		plain = false
+	}
+	if plain {
+		return s
+	}
+	// JSON escapes are valid in YAML double-quoted scalars.
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -3,6 +3,18 @@ module github.com/apexlang/apex-go/cmd/host
 go 1.19
 
 require (
+	github.com/apexlang/apex-go v0.0.0-20261019040146-ed78b153a803
+	github.com/apexlang/apex-go/host v0.0.0-20261019040146-ed78b153a803
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/tetratelabs/wazero v1.0.0-pre.6
 )
+
+require (
+	github.com/CosmWasm/tinyjson v0.9.0 // indirect
+	github.com/iancoleman/strcase v0.2.0 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/tetratelabs/tinymem v0.1.0 // indirect
+	github.com/wapc/tinygo-msgpack v0.1.6 // indirect
+)
+
+replace github.com/CosmWasm/tinyjson v0.9.0 => github.com/apexlang/tinyjson v0.9.1-0.20220929010544-92ef7a6da107
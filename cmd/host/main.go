@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/tetratelabs/wazero"
-	"github.com/tetratelabs/wazero/api"
-	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/apexlang/apex-go/host"
+	"github.com/apexlang/apex-go/workspace"
 )
 
 //go:embed apex-api.wasm
@@ -26,142 +26,52 @@ func main() {
 	ctx := context.Background()
 	specBytes, err := os.ReadFile(specFile)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	config := wazero.NewModuleConfig().
-		WithStdout(os.Stdout).WithStderr(os.Stderr)
-
-	// Create a new WebAssembly Runtime.
-	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
-		WithCoreFeatures(api.CoreFeaturesV2))
-	defer r.Close(ctx)
-
 	homeDir, err := getHomeDirectory()
 	if err != nil {
-		panic(err)
-	}
-
-	definitions := definitions(filepath.Join(homeDir, "definitions"))
-
-	var malloc, free api.Function
-
-	m, err := r.NewHostModuleBuilder("apex").
-		NewFunctionBuilder().
-		WithFunc(definitions.resolve).
-		WithParameterNames("location_ptr", "location_len", "from_ptr", "from_len").
-		Export("resolve").
-		Instantiate(ctx, r)
-	if err != nil {
-		panic(err)
-	}
-	defer m.Close(ctx)
-
-	closer, err := wasi_snapshot_preview1.Instantiate(ctx, r)
-	if err != nil {
-		panic(err)
-	}
-	defer closer.Close(ctx)
-
-	code, err := r.CompileModule(ctx, apexWasm)
-	if err != nil {
-		panic(err)
-	}
-	defer code.Close(ctx)
-
-	g, err := r.InstantiateModule(ctx, code, config)
-	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	defer g.Close(ctx)
-
-	parse := g.ExportedFunction("parse")
-	malloc = g.ExportedFunction("_malloc")
-	free = g.ExportedFunction("_free")
-
-	specSize := uint64(len(specBytes))
+	resolve := workspace.DirResolver(filepath.Join(homeDir, "definitions"))
 
-	results, err := malloc.Call(ctx, specSize)
+	compiler, err := host.NewCompiler(ctx, apexWasm,
+		host.WithResolver(host.ResolverFunc(func(ctx context.Context, location, from string) (string, error) {
+			return resolve(location, from)
+		})),
+		host.WithModuleConfig(wazero.NewModuleConfig().
+			WithStdout(os.Stdout).WithStderr(os.Stderr)),
+	)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
+	defer compiler.Close(ctx)
 
-	bufferPtr := results[0]
-	defer free.Call(ctx, bufferPtr)
-
-	g.Memory().Write(uint32(bufferPtr), specBytes)
-	results, err = parse.Call(ctx, bufferPtr, specSize)
+	result, err := compiler.Parse(ctx, string(specBytes))
 	if err != nil {
-		panic(err)
-	}
-
-	ret := results[0]
-	if ret == 0 {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
-		return
-	}
-	size := uint32(ret & 0xFFFFFFFF)
-	ptr := uint32(ret >> uint64(32))
-
-	docBytes, _ := g.Memory().Read(ptr, size)
-
-	fmt.Println(string(docBytes))
-}
-
-type definitions string
-
-// resolve is defined as a reflective func because it isn't used frequently.
-func (d definitions) resolve(ctx context.Context, m api.Module, locationPtr, locationLen, fromPtr, fromLen uint32) uint64 {
-	locationBuf, ok := m.Memory().Read(locationPtr, locationLen)
-	if !ok {
-		returnString(ctx, m, "out of memory")
 	}
-	location := string(locationBuf)
-
-	loc := filepath.Join(string(d), filepath.Join(strings.Split(location, "/")...))
-	if filepath.Ext(loc) != ".apex" {
-		specLoc := loc + ".apex"
-		found := false
-		stat, err := os.Stat(specLoc)
-		if err == nil && !stat.IsDir() {
-			found = true
-			loc = specLoc
-		}
 
-		if !found {
-			stat, err := os.Stat(loc)
-			if err != nil {
-				return returnString(ctx, m, fmt.Sprintf("error: %v", err))
-			}
-			if stat.IsDir() {
-				loc = filepath.Join(loc, "index.apex")
-			} else {
-				loc += ".apex"
+	if len(result.Errors) > 0 {
+		for _, e := range result.Errors {
+			if len(e.Locations) > 0 {
+				fmt.Fprintf(os.Stderr, "%s:%d:%d: ", specFile, e.Locations[0].Line, e.Locations[0].Column)
 			}
+			fmt.Fprintln(os.Stderr, e.Message)
 		}
+		os.Exit(1)
 	}
 
-	data, err := os.ReadFile(loc)
+	docBytes, err := result.Namespace.MarshalJSON()
 	if err != nil {
-		returnString(ctx, m, fmt.Sprintf("error: %v", err))
-	}
-
-	source := string(data)
-	return returnString(ctx, m, source)
-}
-
-func returnString(ctx context.Context, m api.Module, value string) uint64 {
-	size := uint64(len(value))
-	results, err := m.ExportedFunction("_malloc").Call(ctx, size)
-	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	ptr := uintptr(results[0])
-
-	m.Memory().Write(uint32(ptr), []byte(value))
-	ptrSize := (uint64(ptr) << uint64(32)) | uint64(size)
-	return ptrSize
+	fmt.Println(string(docBytes))
 }
 
 func getHomeDirectory() (string, error) {
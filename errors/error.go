@@ -34,6 +34,8 @@ type Error struct {
 	Locations     []location.SourceLocation `json:"locations,omitempty"`
 	OriginalError error                     `json:"-"`
 	Path          []interface{}             `json:"path,omitempty"`
+	Suggestions   []string                  `json:"suggestions,omitempty"`
+	Edits         []source.TextEdit         `json:"edits,omitempty"`
 }
 
 type Errors []*Error
@@ -43,6 +45,11 @@ func (g Error) Error() string {
 	return fmt.Sprintf("%v", g.Message)
 }
 
+// Unwrap returns the original error, if any.
+func (g *Error) Unwrap() error {
+	return g.OriginalError
+}
+
 func NewError(message string, nodes []ast.Node, stack string, source *source.Source, positions []uint, origError error) *Error {
 	return newError(message, nodes, stack, source, positions, nil, origError)
 }
@@ -126,6 +126,52 @@ func tinyjsonC34e4ef0DecodeGithubComApexlangApexGoErrors(in *jlexer.Lexer, out *
 				}
 				in.Delim(']')
 			}
+		case "suggestions":
+			if in.IsNull() {
+				in.Skip()
+				out.Suggestions = nil
+			} else {
+				in.Delim('[')
+				if out.Suggestions == nil {
+					if !in.IsDelim(']') {
+						out.Suggestions = make([]string, 0, 4)
+					} else {
+						out.Suggestions = []string{}
+					}
+				} else {
+					out.Suggestions = (out.Suggestions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Suggestions = append(out.Suggestions, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "edits":
+			if in.IsNull() {
+				in.Skip()
+				out.Edits = nil
+			} else {
+				in.Delim('[')
+				if out.Edits == nil {
+					if !in.IsDelim(']') {
+						out.Edits = make([]source.TextEdit, 0, 2)
+					} else {
+						out.Edits = []source.TextEdit{}
+					}
+				} else {
+					out.Edits = (out.Edits)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 source.TextEdit
+					tinyjsonC34e4ef0DecodeGithubComApexlangApexGoSource1(in, &v5)
+					out.Edits = append(out.Edits, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -160,11 +206,11 @@ func tinyjsonC34e4ef0EncodeGithubComApexlangApexGoErrors(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v4, v5 := range in.Positions {
-				if v4 > 0 {
+			for v6, v7 := range in.Positions {
+				if v6 > 0 {
 					out.RawByte(',')
 				}
-				out.Uint(uint(v5))
+				out.Uint(uint(v7))
 			}
 			out.RawByte(']')
 		}
@@ -174,11 +220,11 @@ func tinyjsonC34e4ef0EncodeGithubComApexlangApexGoErrors(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v6, v7 := range in.Locations {
-				if v6 > 0 {
+			for v8, v9 := range in.Locations {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				tinyjsonC34e4ef0EncodeGithubComApexlangApexGoLocation(out, v7)
+				tinyjsonC34e4ef0EncodeGithubComApexlangApexGoLocation(out, v9)
 			}
 			out.RawByte(']')
 		}
@@ -188,17 +234,45 @@ func tinyjsonC34e4ef0EncodeGithubComApexlangApexGoErrors(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v8, v9 := range in.Path {
-				if v8 > 0 {
+			for v10, v11 := range in.Path {
+				if v10 > 0 {
 					out.RawByte(',')
 				}
-				if m, ok := v9.(tinyjson.Marshaler); ok {
+				if m, ok := v11.(tinyjson.Marshaler); ok {
 					m.MarshalTinyJSON(out)
-				} else if m, ok := v9.(json.Marshaler); ok {
+				} else if m, ok := v11.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v9))
+					out.Raw(json.Marshal(v11))
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Suggestions) != 0 {
+		const prefix string = ",\"suggestions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Suggestions {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v13))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Edits) != 0 {
+		const prefix string = ",\"edits\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Edits {
+				if v14 > 0 {
+					out.RawByte(',')
 				}
+				tinyjsonC34e4ef0EncodeGithubComApexlangApexGoSource1(out, v15)
 			}
 			out.RawByte(']')
 		}
@@ -229,6 +303,62 @@ func (v *Error) UnmarshalJSON(data []byte) error {
 func (v *Error) UnmarshalTinyJSON(l *jlexer.Lexer) {
 	tinyjsonC34e4ef0DecodeGithubComApexlangApexGoErrors(l, v)
 }
+func tinyjsonC34e4ef0DecodeGithubComApexlangApexGoSource1(in *jlexer.Lexer, out *source.TextEdit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "start":
+			out.Start = uint(in.Uint())
+		case "end":
+			out.End = uint(in.Uint())
+		case "newText":
+			out.NewText = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonC34e4ef0EncodeGithubComApexlangApexGoSource1(out *jwriter.Writer, in source.TextEdit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"start\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Start))
+	}
+	{
+		const prefix string = ",\"end\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.End))
+	}
+	{
+		const prefix string = ",\"newText\":"
+		out.RawString(prefix)
+		out.String(string(in.NewText))
+	}
+	out.RawByte('}')
+}
 func tinyjsonC34e4ef0DecodeGithubComApexlangApexGoLocation(in *jlexer.Lexer, out *location.SourceLocation) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
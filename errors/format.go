@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/apexlang/apex-go/location"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Formatter writes a list of errors to w in a particular format.
+type Formatter interface {
+	Format(w io.Writer, errs Errors) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(w io.Writer, errs Errors) error
+
+func (f FormatterFunc) Format(w io.Writer, errs Errors) error {
+	return f(w, errs)
+}
+
+var formatters = map[string]Formatter{
+	"json":   FormatterFunc(FormatJSON),
+	"sarif":  FormatterFunc(FormatSARIF),
+	"github": FormatterFunc(FormatGitHub),
+	"junit":  FormatterFunc(FormatJUnit),
+	"human":  Human{Color: true},
+	"text":   Human{},
+}
+
+// RegisterFormatter makes a formatter available by name, replacing
+// any formatter previously registered under that name.
+func RegisterFormatter(name string, f Formatter) {
+	formatters[name] = f
+}
+
+// LookupFormatter returns the formatter registered under name.
+func LookupFormatter(name string) (Formatter, bool) {
+	f, ok := formatters[name]
+	return f, ok
+}
+
+// FormatterNames returns the sorted names of all registered formatters.
+func FormatterNames() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatJSON writes errs in the same JSON shape as Write, leaving out
+// the source bodies that would otherwise be repeated for every error.
+func FormatJSON(w io.Writer, errs Errors) error {
+	stripped := make(Errors, len(errs))
+	for i, e := range errs {
+		c := *e
+		if c.Source != nil {
+			c.Source = source.NewSource(c.Source.Name, nil)
+		}
+		stripped[i] = &c
+	}
+	jsonBytes, err := json.Marshal(stripped)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// FormatGitHub writes errs as GitHub Actions workflow commands so they
+// show up as annotations on pull requests.
+func FormatGitHub(w io.Writer, errs Errors) error {
+	for _, e := range errs {
+		var props []string
+		if name := e.sourceName(); name != "" {
+			props = append(props, "file="+escapeGitHubProperty(name))
+		}
+		if l, ok := e.runeLocation(); ok {
+			props = append(props,
+				fmt.Sprintf("line=%d", l.Line),
+				fmt.Sprintf("col=%d", l.Column))
+		}
+		cmd := "::error"
+		if len(props) > 0 {
+			cmd += " " + strings.Join(props, ",")
+		}
+		if _, err := fmt.Fprintf(w, "%s::%s\n", cmd, escapeGitHubData(e.summary())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+	).Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+		":", "%3A",
+		",", "%2C",
+	).Replace(s)
+}
+
+// FormatJUnit writes errs as a JUnit XML report with one failed test
+// case per error.
+func FormatJUnit(w io.Writer, errs Errors) error {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&b, `<testsuites tests="%d" failures="%d">`+"\n", len(errs), len(errs))
+	fmt.Fprintf(&b, `  <testsuite name="apex" tests="%d" failures="%d">`+"\n", len(errs), len(errs))
+	for _, e := range errs {
+		name := e.sourceName()
+		if l, ok := e.runeLocation(); ok {
+			name = fmt.Sprintf("%s:%d:%d", name, l.Line, l.Column)
+		}
+		fmt.Fprintf(&b, `    <testcase classname="%s" name="%s">`+"\n",
+			escapeXML(e.sourceName()), escapeXML(name))
+		fmt.Fprintf(&b, `      <failure type="error" message="%s">%s</failure>`+"\n",
+			escapeXML(e.summary()), escapeXML(e.Message))
+		b.WriteString("    </testcase>\n")
+	}
+	b.WriteString("  </testsuite>\n")
+	b.WriteString("</testsuites>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var xmlEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+	"\n", "&#xA;",
+	"\r", "&#xD;",
+	"\t", "&#x9;",
+)
+
+func escapeXML(s string) string {
+	return xmlEscaper.Replace(s)
+}
+
+// Human writes errs for people reading a terminal: the position of
+// each error followed by the surrounding source lines.
+type Human struct {
+	// Color enables ANSI colours.
+	Color bool
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+)
+
+func (h Human) Format(w io.Writer, errs Errors) error {
+	var b strings.Builder
+	for _, e := range errs {
+		pos := e.sourceName()
+		l, hasLocation := e.runeLocation()
+		if hasLocation {
+			if pos == "" {
+				pos = "<input>"
+			}
+			pos = fmt.Sprintf("%s:%d:%d", pos, l.Line, l.Column)
+		}
+		if pos != "" {
+			b.WriteString(h.paint(ansiBold, pos+":") + " ")
+		}
+		b.WriteString(h.paint(ansiBold+ansiRed, "error:") + " ")
+		b.WriteString(e.summary() + "\n")
+
+		if hasLocation && e.Source != nil && len(e.Source.Body) > 0 {
+			b.WriteString(highlightSource(e.Source, l, h.paint(ansiBold+ansiRed, "^")))
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (h Human) paint(code, s string) string {
+	if !h.Color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// summary returns the first line of the error message. Syntax errors
+// embed the highlighted source after it.
+func (e *Error) summary() string {
+	if i := strings.IndexAny(e.Message, "\r\n"); i >= 0 {
+		return e.Message[:i]
+	}
+	return e.Message
+}
+
+func (e *Error) sourceName() string {
+	if e.Source == nil {
+		return ""
+	}
+	return e.Source.Name
+}
+
+func (e *Error) location() (location.SourceLocation, bool) {
+	if len(e.Locations) == 0 {
+		return location.SourceLocation{}, false
+	}
+	return e.Locations[0], true
+}
+
+// runeLocation returns the first location of e with its column counted
+// in runes, which is what editors and terminals show.
+func (e *Error) runeLocation() (location.SourceLocation, bool) {
+	if len(e.Positions) > 0 && e.Source != nil && len(e.Source.Body) > 0 {
+		return location.GetLocationIn(e.Source, e.Positions[0], source.Runes), true
+	}
+	return e.location()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/location"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// formatErrors returns the errors the formatter tests write. The first
+// is a validation error with a fix after multi-byte and astral plane
+// text, at byte 36, rune column 13 and UTF-16 column 14 of line 2. The
+// second has characters that each format must escape and no source
+// body, so its column is in bytes.
+func formatErrors(t *testing.T) errors.Errors {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource("spec.apex", []byte("namespace \"ns\"\n\"日本語😀\" type foo { a: string }\n")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errs errors.Errors
+	for _, err := range rules.Validate(doc, rules.PascalCaseTypeNames) {
+		errs = append(errs, err.(*errors.Error))
+	}
+	if len(errs) != 1 {
+		t.Fatalf("got errors %v, want one", errs)
+	}
+	return append(errs, &errors.Error{
+		Message:   "100% <wrong> & \"quoted\", 'it's': here\r\nsee the spec",
+		Source:    source.NewSource("dir:a,b.apex", nil),
+		Positions: []uint{4},
+		Locations: []location.SourceLocation{{Line: 1, Column: 5}},
+	}, &errors.Error{
+		Message: "no location",
+	})
+}
+
+func TestFormatters(t *testing.T) {
+	errs := formatErrors(t)
+	for _, name := range errors.FormatterNames() {
+		t.Run(name, func(t *testing.T) {
+			f, _ := errors.LookupFormatter(name)
+			var b bytes.Buffer
+			if err := f.Format(&b, errs); err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", "format."+name)
+			if *update {
+				if err := os.WriteFile(golden, b.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(b.Bytes(), expected) {
+				t.Errorf("output differs from %s\nexpected:\n%s\nactual:\n%s", golden, expected, b.Bytes())
+			}
+		})
+	}
+}
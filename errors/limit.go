@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	stderrs "errors"
+	"fmt"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/location"
+	"github.com/apexlang/apex-go/source"
+)
+
+// ErrLimitExceeded matches the errors returned when parsing stops at a
+// resource limit.
+var ErrLimitExceeded = stderrs.New("limit exceeded")
+
+// LimitError is the original error of a limit error. It names the
+// exceeded limit and its maximum.
+type LimitError struct {
+	Limit string
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s of %d exceeded", e.Limit, e.Max)
+}
+
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// NewLimitError returns the error for exceeding limit at position.
+// The error wraps a *LimitError.
+func NewLimitError(s *source.Source, position uint, limit string, max int, description string) *Error {
+	l := location.GetLocationIn(s, position, source.Runes)
+	return NewError(
+		fmt.Sprintf("Limit Error %s (%d:%d) %s\n\n%s", s.Name, l.Line, l.Column, description, highlightSourceAtLocation(s, l)),
+		[]ast.Node{},
+		"",
+		s,
+		[]uint{position},
+		&LimitError{Limit: limit, Max: max},
+	)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"io"
+
+	"github.com/apexlang/apex-go/location"
+	"github.com/apexlang/apex-go/source"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the root object of a SARIF 2.1.0 log file. Only the
+// properties needed to report Apex diagnostics are modelled.
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool       SARIFTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind,omitempty"`
+	Results    []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri,omitempty"`
+}
+
+type SARIFResult struct {
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations,omitempty"`
+	Fixes     []SARIFFix      `json:"fixes,omitempty"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine   uint  `json:"startLine,omitempty"`
+	StartColumn uint  `json:"startColumn,omitempty"`
+	ByteOffset  *uint `json:"byteOffset,omitempty"`
+	ByteLength  *uint `json:"byteLength,omitempty"`
+}
+
+type SARIFFix struct {
+	Description     SARIFMessage          `json:"description"`
+	ArtifactChanges []SARIFArtifactChange `json:"artifactChanges"`
+}
+
+type SARIFArtifactChange struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Replacements     []SARIFReplacement    `json:"replacements"`
+}
+
+type SARIFReplacement struct {
+	DeletedRegion   SARIFRegion           `json:"deletedRegion"`
+	InsertedContent *SARIFArtifactContent `json:"insertedContent,omitempty"`
+}
+
+type SARIFArtifactContent struct {
+	Text string `json:"text"`
+}
+
+// NewSARIFLog converts errs into a SARIF log with a single run.
+func NewSARIFLog(errs Errors) SARIFLog {
+	results := make([]SARIFResult, 0, len(errs))
+	for _, e := range errs {
+		result := SARIFResult{
+			Level:   "error",
+			Message: SARIFMessage{Text: e.summary()},
+		}
+
+		artifact := SARIFArtifactLocation{URI: e.sourceName()}
+		if l, ok := e.sarifLocation(); ok {
+			region := SARIFRegion{
+				StartLine:   l.Line,
+				StartColumn: l.Column,
+			}
+			result.Locations = []SARIFLocation{{
+				PhysicalLocation: SARIFPhysicalLocation{
+					ArtifactLocation: artifact,
+					Region:           &region,
+				},
+			}}
+		}
+
+		if len(e.Edits) > 0 {
+			replacements := make([]SARIFReplacement, len(e.Edits))
+			for i, edit := range e.Edits {
+				offset, length := edit.Start, edit.End-edit.Start
+				replacements[i].DeletedRegion = SARIFRegion{
+					ByteOffset: &offset,
+					ByteLength: &length,
+				}
+				if edit.NewText != "" {
+					replacements[i].InsertedContent = &SARIFArtifactContent{Text: edit.NewText}
+				}
+			}
+			result.Fixes = []SARIFFix{{
+				Description: SARIFMessage{Text: "Apply the suggested fix"},
+				ArtifactChanges: []SARIFArtifactChange{{
+					ArtifactLocation: artifact,
+					Replacements:     replacements,
+				}},
+			}}
+		}
+
+		results = append(results, result)
+	}
+
+	return SARIFLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           "apex",
+				InformationURI: "https://apexlang.io",
+			}},
+			ColumnKind: "utf16CodeUnits",
+			Results:    results,
+		}},
+	}
+}
+
+// sarifLocation returns the first location of e with its column
+// counted in UTF-16 code units, as SARIF expects. Without the source
+// body the column cannot be converted, so it is left out.
+func (e *Error) sarifLocation() (location.SourceLocation, bool) {
+	if len(e.Positions) > 0 && e.Source != nil && len(e.Source.Body) > 0 {
+		return location.GetLocationIn(e.Source, e.Positions[0], source.UTF16), true
+	}
+	l, ok := e.location()
+	l.Column = 0
+	return l, ok
+}
+
+// FormatSARIF writes errs as a SARIF 2.1.0 log for code scanning tools.
+func FormatSARIF(w io.Writer, errs Errors) error {
+	jsonBytes, err := NewSARIFLog(errs).MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
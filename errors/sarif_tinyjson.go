@@ -0,0 +1,1282 @@
+// Code generated by tinyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package errors
+
+import (
+	tinyjson "github.com/CosmWasm/tinyjson"
+	jlexer "github.com/CosmWasm/tinyjson/jlexer"
+	jwriter "github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ tinyjson.Marshaler
+)
+
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors(in *jlexer.Lexer, out *SARIFTool) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "driver":
+			(out.Driver).UnmarshalTinyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors(out *jwriter.Writer, in SARIFTool) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"driver\":"
+		out.RawString(prefix[1:])
+		(in.Driver).MarshalTinyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFTool) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFTool) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFTool) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFTool) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors1(in *jlexer.Lexer, out *SARIFRun) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tool":
+			(out.Tool).UnmarshalTinyJSON(in)
+		case "columnKind":
+			out.ColumnKind = string(in.String())
+		case "results":
+			if in.IsNull() {
+				in.Skip()
+				out.Results = nil
+			} else {
+				in.Delim('[')
+				if out.Results == nil {
+					if !in.IsDelim(']') {
+						out.Results = make([]SARIFResult, 0, 0)
+					} else {
+						out.Results = []SARIFResult{}
+					}
+				} else {
+					out.Results = (out.Results)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 SARIFResult
+					(v1).UnmarshalTinyJSON(in)
+					out.Results = append(out.Results, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors1(out *jwriter.Writer, in SARIFRun) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"tool\":"
+		out.RawString(prefix[1:])
+		(in.Tool).MarshalTinyJSON(out)
+	}
+	if in.ColumnKind != "" {
+		const prefix string = ",\"columnKind\":"
+		out.RawString(prefix)
+		out.String(string(in.ColumnKind))
+	}
+	{
+		const prefix string = ",\"results\":"
+		out.RawString(prefix)
+		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Results {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFRun) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFRun) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFRun) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFRun) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors1(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors2(in *jlexer.Lexer, out *SARIFResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "level":
+			out.Level = string(in.String())
+		case "message":
+			(out.Message).UnmarshalTinyJSON(in)
+		case "locations":
+			if in.IsNull() {
+				in.Skip()
+				out.Locations = nil
+			} else {
+				in.Delim('[')
+				if out.Locations == nil {
+					if !in.IsDelim(']') {
+						out.Locations = make([]SARIFLocation, 0, 2)
+					} else {
+						out.Locations = []SARIFLocation{}
+					}
+				} else {
+					out.Locations = (out.Locations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 SARIFLocation
+					(v4).UnmarshalTinyJSON(in)
+					out.Locations = append(out.Locations, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "fixes":
+			if in.IsNull() {
+				in.Skip()
+				out.Fixes = nil
+			} else {
+				in.Delim('[')
+				if out.Fixes == nil {
+					if !in.IsDelim(']') {
+						out.Fixes = make([]SARIFFix, 0, 1)
+					} else {
+						out.Fixes = []SARIFFix{}
+					}
+				} else {
+					out.Fixes = (out.Fixes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 SARIFFix
+					(v5).UnmarshalTinyJSON(in)
+					out.Fixes = append(out.Fixes, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors2(out *jwriter.Writer, in SARIFResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"level\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Level))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		(in.Message).MarshalTinyJSON(out)
+	}
+	if len(in.Locations) != 0 {
+		const prefix string = ",\"locations\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.Locations {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				(v7).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Fixes) != 0 {
+		const prefix string = ",\"fixes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Fixes {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFResult) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFResult) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors2(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors3(in *jlexer.Lexer, out *SARIFReplacement) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "deletedRegion":
+			(out.DeletedRegion).UnmarshalTinyJSON(in)
+		case "insertedContent":
+			if in.IsNull() {
+				in.Skip()
+				out.InsertedContent = nil
+			} else {
+				if out.InsertedContent == nil {
+					out.InsertedContent = new(SARIFArtifactContent)
+				}
+				(*out.InsertedContent).UnmarshalTinyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors3(out *jwriter.Writer, in SARIFReplacement) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"deletedRegion\":"
+		out.RawString(prefix[1:])
+		(in.DeletedRegion).MarshalTinyJSON(out)
+	}
+	if in.InsertedContent != nil {
+		const prefix string = ",\"insertedContent\":"
+		out.RawString(prefix)
+		(*in.InsertedContent).MarshalTinyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFReplacement) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFReplacement) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFReplacement) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFReplacement) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors3(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors4(in *jlexer.Lexer, out *SARIFRegion) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "startLine":
+			out.StartLine = uint(in.Uint())
+		case "startColumn":
+			out.StartColumn = uint(in.Uint())
+		case "byteOffset":
+			if in.IsNull() {
+				in.Skip()
+				out.ByteOffset = nil
+			} else {
+				if out.ByteOffset == nil {
+					out.ByteOffset = new(uint)
+				}
+				*out.ByteOffset = uint(in.Uint())
+			}
+		case "byteLength":
+			if in.IsNull() {
+				in.Skip()
+				out.ByteLength = nil
+			} else {
+				if out.ByteLength == nil {
+					out.ByteLength = new(uint)
+				}
+				*out.ByteLength = uint(in.Uint())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors4(out *jwriter.Writer, in SARIFRegion) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.StartLine != 0 {
+		const prefix string = ",\"startLine\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.StartLine))
+	}
+	if in.StartColumn != 0 {
+		const prefix string = ",\"startColumn\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.StartColumn))
+	}
+	if in.ByteOffset != nil {
+		const prefix string = ",\"byteOffset\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(*in.ByteOffset))
+	}
+	if in.ByteLength != nil {
+		const prefix string = ",\"byteLength\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(*in.ByteLength))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFRegion) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFRegion) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFRegion) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFRegion) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors4(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors5(in *jlexer.Lexer, out *SARIFPhysicalLocation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "artifactLocation":
+			(out.ArtifactLocation).UnmarshalTinyJSON(in)
+		case "region":
+			if in.IsNull() {
+				in.Skip()
+				out.Region = nil
+			} else {
+				if out.Region == nil {
+					out.Region = new(SARIFRegion)
+				}
+				(*out.Region).UnmarshalTinyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors5(out *jwriter.Writer, in SARIFPhysicalLocation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"artifactLocation\":"
+		out.RawString(prefix[1:])
+		(in.ArtifactLocation).MarshalTinyJSON(out)
+	}
+	if in.Region != nil {
+		const prefix string = ",\"region\":"
+		out.RawString(prefix)
+		(*in.Region).MarshalTinyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFPhysicalLocation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFPhysicalLocation) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFPhysicalLocation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFPhysicalLocation) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors5(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors6(in *jlexer.Lexer, out *SARIFMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors6(out *jwriter.Writer, in SARIFMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFMessage) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFMessage) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors6(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors7(in *jlexer.Lexer, out *SARIFLog) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "$schema":
+			out.Schema = string(in.String())
+		case "runs":
+			if in.IsNull() {
+				in.Skip()
+				out.Runs = nil
+			} else {
+				in.Delim('[')
+				if out.Runs == nil {
+					if !in.IsDelim(']') {
+						out.Runs = make([]SARIFRun, 0, 0)
+					} else {
+						out.Runs = []SARIFRun{}
+					}
+				} else {
+					out.Runs = (out.Runs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 SARIFRun
+					(v10).UnmarshalTinyJSON(in)
+					out.Runs = append(out.Runs, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors7(out *jwriter.Writer, in SARIFLog) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"$schema\":"
+		out.RawString(prefix)
+		out.String(string(in.Schema))
+	}
+	{
+		const prefix string = ",\"runs\":"
+		out.RawString(prefix)
+		if in.Runs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Runs {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFLog) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFLog) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFLog) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFLog) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors7(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors8(in *jlexer.Lexer, out *SARIFLocation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "physicalLocation":
+			(out.PhysicalLocation).UnmarshalTinyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors8(out *jwriter.Writer, in SARIFLocation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"physicalLocation\":"
+		out.RawString(prefix[1:])
+		(in.PhysicalLocation).MarshalTinyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFLocation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFLocation) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFLocation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFLocation) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors8(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors9(in *jlexer.Lexer, out *SARIFFix) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "description":
+			(out.Description).UnmarshalTinyJSON(in)
+		case "artifactChanges":
+			if in.IsNull() {
+				in.Skip()
+				out.ArtifactChanges = nil
+			} else {
+				in.Delim('[')
+				if out.ArtifactChanges == nil {
+					if !in.IsDelim(']') {
+						out.ArtifactChanges = make([]SARIFArtifactChange, 0, 1)
+					} else {
+						out.ArtifactChanges = []SARIFArtifactChange{}
+					}
+				} else {
+					out.ArtifactChanges = (out.ArtifactChanges)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 SARIFArtifactChange
+					(v13).UnmarshalTinyJSON(in)
+					out.ArtifactChanges = append(out.ArtifactChanges, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors9(out *jwriter.Writer, in SARIFFix) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix[1:])
+		(in.Description).MarshalTinyJSON(out)
+	}
+	{
+		const prefix string = ",\"artifactChanges\":"
+		out.RawString(prefix)
+		if in.ArtifactChanges == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.ArtifactChanges {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFFix) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFFix) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFFix) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFFix) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors9(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors10(in *jlexer.Lexer, out *SARIFDriver) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "informationUri":
+			out.InformationURI = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors10(out *jwriter.Writer, in SARIFDriver) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.InformationURI != "" {
+		const prefix string = ",\"informationUri\":"
+		out.RawString(prefix)
+		out.String(string(in.InformationURI))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFDriver) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFDriver) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFDriver) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFDriver) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors10(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors11(in *jlexer.Lexer, out *SARIFArtifactLocation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "uri":
+			out.URI = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors11(out *jwriter.Writer, in SARIFArtifactLocation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uri\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URI))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFArtifactLocation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFArtifactLocation) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFArtifactLocation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFArtifactLocation) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors11(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors12(in *jlexer.Lexer, out *SARIFArtifactContent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors12(out *jwriter.Writer, in SARIFArtifactContent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFArtifactContent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFArtifactContent) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFArtifactContent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFArtifactContent) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors12(l, v)
+}
+func tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors13(in *jlexer.Lexer, out *SARIFArtifactChange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "artifactLocation":
+			(out.ArtifactLocation).UnmarshalTinyJSON(in)
+		case "replacements":
+			if in.IsNull() {
+				in.Skip()
+				out.Replacements = nil
+			} else {
+				in.Delim('[')
+				if out.Replacements == nil {
+					if !in.IsDelim(']') {
+						out.Replacements = make([]SARIFReplacement, 0, 1)
+					} else {
+						out.Replacements = []SARIFReplacement{}
+					}
+				} else {
+					out.Replacements = (out.Replacements)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 SARIFReplacement
+					(v16).UnmarshalTinyJSON(in)
+					out.Replacements = append(out.Replacements, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors13(out *jwriter.Writer, in SARIFArtifactChange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"artifactLocation\":"
+		out.RawString(prefix[1:])
+		(in.ArtifactLocation).MarshalTinyJSON(out)
+	}
+	{
+		const prefix string = ",\"replacements\":"
+		out.RawString(prefix)
+		if in.Replacements == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v17, v18 := range in.Replacements {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SARIFArtifactChange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v SARIFArtifactChange) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonEa002e9dEncodeGithubComApexlangApexGoErrors13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SARIFArtifactChange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *SARIFArtifactChange) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonEa002e9dDecodeGithubComApexlangApexGoErrors13(l, v)
+}
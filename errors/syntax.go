@@ -18,16 +18,18 @@ package errors
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/apexlang/apex-go/ast"
 	"github.com/apexlang/apex-go/location"
 	"github.com/apexlang/apex-go/source"
 )
 
+// NewSyntaxError returns the error for a syntax error at position. Its
+// message counts the column in runes, while Locations count it in bytes.
 func NewSyntaxError(s *source.Source, position uint, description string) *Error {
-	l := location.GetLocation(s, position)
+	l := location.GetLocationIn(s, position, source.Runes)
 	return NewError(
 		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", s.Name, l.Line, l.Column, description, highlightSourceAtLocation(s, l)),
 		[]ast.Node{},
@@ -57,27 +59,45 @@ func printLine(str string) string {
 }
 
 func highlightSourceAtLocation(s *source.Source, l location.SourceLocation) string {
+	return highlightSource(s, l, "^")
+}
+
+// highlightSource returns the lines around l with caret marking the
+// column below the line of l. The column of l is counted in runes.
+func highlightSource(s *source.Source, l location.SourceLocation, caret string) string {
 	line := l.Line
 	prevLineNum := fmt.Sprintf("%d", (line - 1))
 	lineNum := fmt.Sprintf("%d", line)
 	nextLineNum := fmt.Sprintf("%d", (line + 1))
 	padLen := len(nextLineNum)
-	lines := regexp.MustCompile("\r\n|[\n\r]").Split(string(s.Body), -1)
 	var highlight string
 	if line >= 2 {
-		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(lines[line-2]))
+		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(string(s.Line(line-1))))
 	}
-	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(lines[line-1]))
-	for i := 1; i < (2 + padLen + int(l.Column)); i++ {
-		highlight += " "
-	}
-	highlight += "^\n"
-	if int(line) < len(lines) {
-		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(lines[line]))
+	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(string(s.Line(line))))
+	highlight += strings.Repeat(" ", 2+padLen+indent(s.Line(line), l.Column))
+	highlight += caret + "\n"
+	if line < s.LineCount() {
+		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(string(s.Line(line+1))))
 	}
 	return highlight
 }
 
+// indent returns the printed width of the text before column, counted
+// in runes, of text. Control characters are printed escaped and columns
+// past the end of the text are one space each.
+func indent(text []byte, column uint) int {
+	if column < 1 {
+		return 0
+	}
+	runes := []rune(string(text))
+	n := int(column) - 1
+	if n > len(runes) {
+		return utf8.RuneCountInString(printLine(string(runes))) + n - len(runes)
+	}
+	return utf8.RuneCountInString(printLine(string(runes[:n])))
+}
+
 func lpad(l int, s string) string {
 	var r string
 	for i := 1; i < (l - len(s) + 1); i++ {
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestSyntaxErrorColumns(t *testing.T) {
+	tests := []struct {
+		body    string
+		line    string
+		message string
+		column  uint
+	}{
+		{"type T { a: string % }", "1: type T { a: string % }", "(1:20)", 20},
+		{`type T { "日本語" a: string % }`, `1: type T { "日本語" a: string % }`, "(1:26)", 32},
+		{"type T {\n\ta: string % }", `2: \u0009a: string % }`, "(2:12)", 12},
+	}
+	for _, tt := range tests {
+		s := source.NewSource("test.apex", []byte(tt.body))
+		e := errors.NewSyntaxError(s, uint(strings.Index(tt.body, "%")), "Unexpected character")
+		if !strings.Contains(e.Message, tt.message) {
+			t.Errorf("%q: message %q does not contain %s", tt.body, e.Message, tt.message)
+		}
+		// The caret is below the % in the printed line.
+		found := false
+		lines := strings.Split(e.Message, "\n")
+		for i, line := range lines[:len(lines)-1] {
+			if strings.HasSuffix(line, tt.line) {
+				found = true
+				caret := strings.Index(lines[i+1], "^")
+				if want := len([]rune(tt.line[:strings.Index(tt.line, "%")])); caret != want {
+					t.Errorf("%q: caret at %d, want %d", tt.body, caret, want)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q: message %q does not print the line", tt.body, e.Message)
+		}
+		// Locations keep counting columns in bytes.
+		if l := e.Locations[0]; l.Column != tt.column {
+			t.Errorf("%q: location column %d, want %d", tt.body, l.Column, tt.column)
+		}
+	}
+}
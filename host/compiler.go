@@ -0,0 +1,293 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package host runs the Apex parser compiled to WebAssembly
+// (apex-api.wasm) in a wazero sandbox.
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/apexlang/apex-go/abi"
+	"github.com/apexlang/apex-go/model"
+)
+
+// DefaultPoolSize is the default number of idle instances kept for
+// reuse.
+const DefaultPoolSize = 4
+
+// Compiler compiles apex-api.wasm once and parses specs with a pool of
+// module instances. It is safe for concurrent use.
+//
+// apex-api.wasm only exports parse, so Compiler is a model.Parser but
+// not a model.ParserService. Use the waPC module for the other
+// operations.
+type Compiler struct {
+	runtime  wazero.Runtime
+	code     wazero.CompiledModule
+	config   wazero.ModuleConfig
+	resolver model.Resolver
+	pool     chan *instance
+	counter  uint64
+	// instances maps module names to instances for resolve.
+	instances sync.Map
+}
+
+// Option configures a Compiler.
+type Option func(*Compiler)
+
+// WithResolver sets the resolver used for imports. Without one, every
+// import fails to resolve.
+func WithResolver(resolver model.Resolver) Option {
+	return func(c *Compiler) {
+		c.resolver = resolver
+	}
+}
+
+// WithPoolSize sets the number of idle instances kept for reuse.
+func WithPoolSize(size int) Option {
+	return func(c *Compiler) {
+		c.pool = make(chan *instance, size)
+	}
+}
+
+// WithModuleConfig sets the configuration instances are created with,
+// for example to capture their stdout and stderr.
+func WithModuleConfig(config wazero.ModuleConfig) Option {
+	return func(c *Compiler) {
+		c.config = config
+	}
+}
+
+// ResolverFunc adapts a function to the model.Resolver interface.
+type ResolverFunc func(ctx context.Context, location string, from string) (string, error)
+
+func (f ResolverFunc) Resolve(ctx context.Context, location string, from string) (string, error) {
+	return f(ctx, location, from)
+}
+
+// NewCompiler compiles the apex-api.wasm module in wasm.
+func NewCompiler(ctx context.Context, wasm []byte, options ...Option) (*Compiler, error) {
+	c := Compiler{
+		config: wazero.NewModuleConfig(),
+		pool:   make(chan *instance, DefaultPoolSize),
+	}
+	for _, option := range options {
+		option(&c)
+	}
+
+	c.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCoreFeatures(api.CoreFeaturesV2))
+
+	if _, err := c.runtime.NewHostModuleBuilder("apex").
+		NewFunctionBuilder().
+		WithFunc(c.resolve).
+		WithParameterNames("location_ptr", "location_len", "from_ptr", "from_len").
+		Export("resolve").
+		Instantiate(ctx, c.runtime); err != nil {
+		c.runtime.Close(ctx)
+		return nil, err
+	}
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, c.runtime); err != nil {
+		c.runtime.Close(ctx)
+		return nil, err
+	}
+
+	code, err := c.runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		c.runtime.Close(ctx)
+		return nil, err
+	}
+	c.code = code
+
+	return &c, nil
+}
+
+// Close releases the instances and the runtime.
+func (c *Compiler) Close(ctx context.Context) error {
+	for {
+		select {
+		case inst := <-c.pool:
+			c.close(ctx, inst)
+		default:
+			return c.runtime.Close(ctx)
+		}
+	}
+}
+
+// Parse parses, validates and converts source. Diagnostics for the
+// spec are returned in the result. Other failures, including those of
+// the resolver, are returned as an error, which is an *abi.Error if
+// the module reported it.
+func (c *Compiler) Parse(ctx context.Context, source string) (*model.ParserResult, error) {
+	inst, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := inst.call(ctx, source)
+	var abiErr *abi.Error
+	if err != nil && !errors.As(err, &abiErr) {
+		// The instance may be left in a broken state.
+		c.close(ctx, inst)
+		return nil, err
+	}
+	c.release(ctx, inst)
+
+	return result, err
+}
+
+// acquire returns an idle instance or creates a new one.
+func (c *Compiler) acquire(ctx context.Context) (*instance, error) {
+	select {
+	case inst := <-c.pool:
+		return inst, nil
+	default:
+	}
+
+	// Module names must be unique within the runtime.
+	name := fmt.Sprintf("apex-%d", atomic.AddUint64(&c.counter, 1))
+	module, err := c.runtime.InstantiateModule(ctx, c.code, c.config.WithName(name))
+	if err != nil {
+		return nil, err
+	}
+	inst := instance{
+		module: module,
+		parse:  module.ExportedFunction("parse"),
+		malloc: module.ExportedFunction("_malloc"),
+		free:   module.ExportedFunction("_free"),
+	}
+	if inst.parse == nil || inst.malloc == nil || inst.free == nil {
+		module.Close(ctx)
+		return nil, errors.New("module does not export parse, _malloc and _free")
+	}
+	c.instances.Store(name, &inst)
+	return &inst, nil
+}
+
+// release returns an instance to the pool or closes it if the pool is
+// full.
+func (c *Compiler) release(ctx context.Context, inst *instance) {
+	select {
+	case c.pool <- inst:
+	default:
+		c.close(ctx, inst)
+	}
+}
+
+func (c *Compiler) close(ctx context.Context, inst *instance) {
+	c.instances.Delete(inst.module.Name())
+	inst.module.Close(ctx)
+}
+
+// resolve implements the apex.resolve host function. The response is
+// allocated in the calling instance, which frees it once parse returns.
+func (c *Compiler) resolve(ctx context.Context, m api.Module, locationPtr, locationLen, fromPtr, fromLen uint32) uint64 {
+	var response []byte
+	location, locationOK := m.Memory().Read(locationPtr, locationLen)
+	from, fromOK := m.Memory().Read(fromPtr, fromLen)
+	switch {
+	case !locationOK || !fromOK:
+		response = abi.EncodeErrors(abi.StatusError, errors.New("location or from is out of range"))
+	case c.resolver == nil:
+		response = abi.EncodeResolve("", fmt.Errorf("could not resolve %q: %w", location, abi.ErrNotFound))
+	default:
+		response = abi.EncodeResolve(c.resolver.Resolve(ctx, string(location), string(from)))
+	}
+
+	ptr, ok := writeResponse(ctx, m, response)
+	if !ok {
+		return 0
+	}
+	if inst, ok := c.instances.Load(m.Name()); ok {
+		inst := inst.(*instance)
+		inst.allocated = append(inst.allocated, ptr)
+	}
+	return abi.Pack(ptr, uint32(len(response)))
+}
+
+// writeResponse copies response into memory allocated by the module.
+func writeResponse(ctx context.Context, m api.Module, response []byte) (uint32, bool) {
+	malloc := m.ExportedFunction("_malloc")
+	if malloc == nil {
+		return 0, false
+	}
+	results, err := malloc.Call(ctx, uint64(len(response)))
+	if err != nil || len(results) != 1 {
+		return 0, false
+	}
+	ptr := uint32(results[0])
+	if !m.Memory().Write(ptr, response) {
+		return 0, false
+	}
+	return ptr, true
+}
+
+type instance struct {
+	module api.Module
+	parse  api.Function
+	malloc api.Function
+	free   api.Function
+	// allocated are the responses to resolve calls that are freed
+	// once the current call to parse returns.
+	allocated []uint32
+}
+
+func (inst *instance) call(ctx context.Context, source string) (*model.ParserResult, error) {
+	defer inst.freeAllocated(ctx)
+
+	size := uint64(len(source))
+	results, err := inst.malloc.Call(ctx, size)
+	if err != nil {
+		return nil, err
+	}
+	ptr := results[0]
+	defer inst.free.Call(ctx, ptr)
+
+	if !inst.module.Memory().Write(uint32(ptr), []byte(source)) {
+		return nil, errors.New("could not write the source to module memory")
+	}
+
+	results, err = inst.parse.Call(ctx, ptr, size)
+	if err != nil {
+		return nil, err
+	}
+	if results[0] == 0 {
+		return nil, fmt.Errorf("parse: %w", abi.ErrNoResponse)
+	}
+
+	response, ok := inst.module.Memory().Read(abi.Unpack(results[0]))
+	if !ok {
+		return nil, errors.New("parse response is out of range")
+	}
+	return abi.DecodeParse(response)
+}
+
+func (inst *instance) freeAllocated(ctx context.Context) {
+	for _, ptr := range inst.allocated {
+		inst.free.Call(ctx, uint64(ptr))
+	}
+	inst.allocated = inst.allocated[:0]
+}
@@ -0,0 +1,470 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/abi"
+)
+
+// The tests run modules assembled below that stand in for
+// apex-api.wasm. Their parse function either returns a constant or
+// calls apex.resolve and returns its response, which is a valid parse
+// response if the resolved source is a namespace in JSON.
+
+const (
+	dataOffset = 4096
+	sourceSize = 100
+)
+
+// Function types
+const (
+	typeMalloc = iota
+	typeFree
+	typeParse
+	typeResolve
+)
+
+type wasmFunc struct {
+	export string
+	typ    byte
+	body   []byte
+}
+
+type wasmModule struct {
+	importResolve bool
+	funcs         []wasmFunc
+	data          []byte
+}
+
+func (m wasmModule) bytes() []byte {
+	out := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	section := func(id byte, items ...[]byte) {
+		content := uleb(uint64(len(items)))
+		for _, item := range items {
+			content = append(content, item...)
+		}
+		out = append(out, id)
+		out = append(out, uleb(uint64(len(content)))...)
+		out = append(out, content...)
+	}
+	const i32, i64 = 0x7f, 0x7e
+
+	section(1,
+		[]byte{0x60, 1, i32, 1, i32},
+		[]byte{0x60, 1, i32, 0},
+		[]byte{0x60, 2, i32, i32, 1, i64},
+		[]byte{0x60, 4, i32, i32, i32, i32, 1, i64},
+	)
+	imported := 0
+	if m.importResolve {
+		imported = 1
+		section(2, concat(name("apex"), name("resolve"), []byte{0x00, typeResolve}))
+	}
+	var types, exports, bodies [][]byte
+	exports = append(exports, concat(name("memory"), []byte{0x02, 0}))
+	exports = append(exports, concat(name("frees"), []byte{0x03, 1}))
+	for i, f := range m.funcs {
+		types = append(types, []byte{f.typ})
+		exports = append(exports, concat(name(f.export), []byte{0x00}, uleb(uint64(imported+i))))
+		body := concat([]byte{0x00}, f.body, []byte{0x0b})
+		bodies = append(bodies, concat(uleb(uint64(len(body))), body))
+	}
+	section(3, types...)
+	section(5, []byte{0x00, 1})
+	section(6,
+		concat([]byte{i32, 1, 0x41}, sleb(1024), []byte{0x0b}),
+		[]byte{i32, 1, 0x41, 0, 0x0b},
+	)
+	section(7, exports...)
+	section(10, bodies...)
+	if m.data != nil {
+		section(11, concat([]byte{0x00, 0x41}, sleb(dataOffset), []byte{0x0b}, uleb(uint64(len(m.data))), m.data))
+	}
+	return out
+}
+
+// malloc is a bump allocator that traps for sizes other than
+// sourceSize if strict is set.
+func malloc(strict bool) wasmFunc {
+	var body []byte
+	if strict {
+		body = concat([]byte{0x20, 0, 0x41}, sleb(sourceSize), []byte{0x47, 0x04, 0x40, 0x00, 0x0b})
+	}
+	body = append(body, 0x23, 0, 0x23, 0, 0x20, 0, 0x6a, 0x24, 0)
+	return wasmFunc{"_malloc", typeMalloc, body}
+}
+
+// free counts its calls in the exported frees global.
+var free = wasmFunc{"_free", typeFree, []byte{0x23, 1, 0x41, 1, 0x6a, 0x24, 1}}
+
+func returnParse(ptrSize uint64) wasmFunc {
+	return wasmFunc{"parse", typeParse, concat([]byte{0x42}, sleb(int64(ptrSize)))}
+}
+
+var trapParse = wasmFunc{"parse", typeParse, []byte{0x00}}
+
+// resolveParse resolves the location at ptr with size.
+func resolveParse(ptr, size uint32) wasmFunc {
+	return wasmFunc{"parse", typeParse, concat(
+		[]byte{0x41}, sleb(int64(ptr)),
+		[]byte{0x41}, sleb(int64(size)),
+		[]byte{0x41, 0, 0x41, 0, 0x10, 0},
+	)}
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, part := range parts {
+		out = append(out, part...)
+	}
+	return out
+}
+
+func name(s string) []byte {
+	return concat(uleb(uint64(len(s))), []byte(s))
+}
+
+func uleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func sleb(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func newCompiler(t *testing.T, m wasmModule, options ...Option) *Compiler {
+	t.Helper()
+	ctx := context.Background()
+	c, err := NewCompiler(ctx, m.bytes(), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close(ctx) })
+	return c
+}
+
+func countInstances(c *Compiler) int {
+	count := 0
+	c.instances.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func TestParseResponses(t *testing.T) {
+	namespace := abi.Encode(abi.StatusOK, []byte(`{"name":"test"}`))
+	invalid := abi.EncodeErrors(abi.StatusInvalid, errors.New("first"), errors.New("second"))
+	failed := abi.EncodeErrors(abi.StatusError, errors.New("failed"))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		parse   wasmFunc
+		ns      string
+		errors  []string
+		err     string
+		discard bool
+	}{
+		{
+			name:  "ok",
+			data:  namespace,
+			parse: returnParse(abi.Pack(dataOffset, uint32(len(namespace)))),
+			ns:    "test",
+		},
+		{
+			name:   "diagnostics",
+			data:   invalid,
+			parse:  returnParse(abi.Pack(dataOffset, uint32(len(invalid)))),
+			errors: []string{"first", "second"},
+		},
+		{
+			name:  "error status",
+			data:  failed,
+			parse: returnParse(abi.Pack(dataOffset, uint32(len(failed)))),
+			err:   "failed",
+		},
+		{
+			name:    "no response",
+			parse:   returnParse(0),
+			err:     "parse: no response",
+			discard: true,
+		},
+		{
+			name:    "out of range",
+			parse:   returnParse(abi.Pack(1<<20, 10)),
+			err:     "parse response is out of range",
+			discard: true,
+		},
+		{
+			name:    "empty response",
+			parse:   returnParse(abi.Pack(dataOffset, 0)),
+			err:     "empty response",
+			discard: true,
+		},
+		{
+			name:    "unknown status",
+			data:    []byte{42},
+			parse:   returnParse(abi.Pack(dataOffset, 1)),
+			err:     "unknown response status 42",
+			discard: true,
+		},
+		{
+			name:    "malformed namespace",
+			data:    abi.Encode(abi.StatusOK, []byte("{")),
+			parse:   returnParse(abi.Pack(dataOffset, 2)),
+			err:     "invalid namespace",
+			discard: true,
+		},
+		{
+			name:    "trap",
+			parse:   trapParse,
+			err:     "wasm error: unreachable",
+			discard: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCompiler(t, wasmModule{
+				funcs: []wasmFunc{malloc(false), free, tt.parse},
+				data:  tt.data,
+			})
+
+			result, err := c.Parse(context.Background(), strings.Repeat(" ", sourceSize))
+			switch {
+			case tt.ns != "":
+				if err != nil || result.Namespace == nil || result.Namespace.Name != tt.ns {
+					t.Fatalf("got %+v, %v", result, err)
+				}
+			case tt.errors != nil:
+				if err != nil || len(result.Errors) != len(tt.errors) {
+					t.Fatalf("got %+v, %v", result, err)
+				}
+				for i, e := range result.Errors {
+					if e.Message != tt.errors[i] {
+						t.Errorf("got error %q, want %q", e.Message, tt.errors[i])
+					}
+				}
+			default:
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got error %v, want %q", err, tt.err)
+				}
+			}
+
+			if instances := countInstances(c); instances != map[bool]int{true: 0, false: 1}[tt.discard] {
+				t.Fatalf("got %d instances, discard: %v", instances, tt.discard)
+			}
+		})
+	}
+}
+
+func TestParseErrorStatus(t *testing.T) {
+	response := abi.EncodeErrors(abi.StatusError, errors.New("failed"))
+	c := newCompiler(t, wasmModule{
+		funcs: []wasmFunc{malloc(false), free, returnParse(abi.Pack(dataOffset, uint32(len(response))))},
+		data:  response,
+	})
+
+	_, err := c.Parse(context.Background(), "")
+	var abiErr *abi.Error
+	if !errors.As(err, &abiErr) || abiErr.Status != abi.StatusError {
+		t.Fatalf("got error %v, want an *abi.Error with status error", err)
+	}
+}
+
+func TestMissingExports(t *testing.T) {
+	c := newCompiler(t, wasmModule{
+		funcs: []wasmFunc{malloc(false), returnParse(0)},
+	})
+
+	_, err := c.Parse(context.Background(), "")
+	if err == nil || err.Error() != "module does not export parse, _malloc and _free" {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestInvalidModule(t *testing.T) {
+	_, err := NewCompiler(context.Background(), []byte("not wasm"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	location := []byte("@apexlang/core")
+	parse := resolveParse(dataOffset, uint32(len(location)))
+
+	resolver := ResolverFunc(func(ctx context.Context, loc, from string) (string, error) {
+		switch loc {
+		case "@apexlang/core":
+			return `{"name":"resolved"}`, nil
+		case "missing":
+			return "", fmt.Errorf("open missing.apex: %w", fs.ErrNotExist)
+		}
+		return "", errors.New("connection reset")
+	})
+
+	tests := []struct {
+		name     string
+		location []byte
+		parse    wasmFunc
+		resolver Option
+		strict   bool
+		ns       string
+		status   abi.Status
+		err      string
+	}{
+		{
+			name:     "ok",
+			location: location,
+			parse:    parse,
+			resolver: WithResolver(resolver),
+			ns:       "resolved",
+		},
+		{
+			name:     "not found",
+			location: []byte("missing"),
+			parse:    resolveParse(dataOffset, 7),
+			resolver: WithResolver(resolver),
+			status:   abi.StatusNotFound,
+			err:      "open missing.apex: file does not exist",
+		},
+		{
+			name:     "resolver error",
+			location: []byte("unknown"),
+			parse:    resolveParse(dataOffset, 7),
+			resolver: WithResolver(resolver),
+			status:   abi.StatusError,
+			err:      "connection reset",
+		},
+		{
+			name:     "no resolver",
+			location: location,
+			parse:    parse,
+			status:   abi.StatusNotFound,
+			err:      `could not resolve "@apexlang/core": not found`,
+		},
+		{
+			name:     "location out of range",
+			parse:    resolveParse(1<<20, 10),
+			resolver: WithResolver(resolver),
+			status:   abi.StatusError,
+			err:      "location or from is out of range",
+		},
+		{
+			name:     "allocation failure",
+			location: location,
+			parse:    parse,
+			resolver: WithResolver(resolver),
+			strict:   true,
+			err:      "parse: no response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options []Option
+			if tt.resolver != nil {
+				options = append(options, tt.resolver)
+			}
+			c := newCompiler(t, wasmModule{
+				importResolve: true,
+				funcs:         []wasmFunc{malloc(tt.strict), free, tt.parse},
+				data:          tt.location,
+			}, options...)
+
+			result, err := c.Parse(context.Background(), strings.Repeat(" ", sourceSize))
+			if tt.ns != "" {
+				if err != nil || result.Namespace == nil || result.Namespace.Name != tt.ns {
+					t.Fatalf("got %+v, %v", result, err)
+				}
+				// Both the source and the resolve response are freed.
+				inst := <-c.pool
+				if frees := inst.module.ExportedGlobal("frees").Get(); frees != 2 {
+					t.Fatalf("got %d frees, want 2", frees)
+				}
+				if len(inst.allocated) != 0 {
+					t.Fatalf("got %d allocations left", len(inst.allocated))
+				}
+				c.pool <- inst
+				return
+			}
+
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("got error %v, want %q", err, tt.err)
+			}
+			var abiErr *abi.Error
+			if errors.As(err, &abiErr) != (tt.status != abi.StatusOK) {
+				t.Fatalf("got %T", err)
+			}
+			if abiErr != nil && abiErr.Status != tt.status {
+				t.Fatalf("got status %v, want %v", abiErr.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestConcurrentParse(t *testing.T) {
+	response := abi.Encode(abi.StatusOK, []byte(`{"name":"test"}`))
+	c := newCompiler(t, wasmModule{
+		funcs: []wasmFunc{malloc(false), free, returnParse(abi.Pack(dataOffset, uint32(len(response))))},
+		data:  response,
+	}, WithPoolSize(2))
+
+	errs := make(chan error, 16)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			result, err := c.Parse(context.Background(), "namespace \"test\"")
+			if err == nil && result.Namespace.Name != "test" {
+				err = fmt.Errorf("got namespace %q", result.Namespace.Name)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+	if instances := countInstances(c); instances > 2 {
+		t.Fatalf("got %d instances, want at most 2", instances)
+	}
+}
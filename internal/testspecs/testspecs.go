@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testspecs loads the specs shared by the tests and fuzz targets
+// of the other packages: the files in testdata and model.axdl.
+package testspecs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Load returns the contents of the shared specs.
+func Load(tb testing.TB) [][]byte {
+	tb.Helper()
+	root := root(tb)
+	files, err := filepath.Glob(filepath.Join(root, "testdata", "*.axdl"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	files = append(files, filepath.Join(root, "model.axdl"))
+	specs := make([][]byte, len(files))
+	for i, file := range files {
+		if specs[i], err = os.ReadFile(file); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return specs
+}
+
+// root returns the directory of the module, which holds this package
+// in internal/testspecs.
+func root(tb testing.TB) string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		tb.Fatal("testspecs: cannot locate the module")
+	}
+	return filepath.Join(filepath.Dir(file), "..", "..")
+}
@@ -17,9 +17,7 @@ limitations under the License.
 package lexer
 
 import (
-	"bytes"
 	"fmt"
-	"regexp"
 	"strings"
 	"unicode/utf8"
 
@@ -27,6 +25,7 @@ import (
 	"github.com/apexlang/apex-go/source"
 )
 
+// Token kinds.
 const (
 	EOF = iota + 1
 	BANG
@@ -66,60 +65,63 @@ const (
 	DIRECTIVE = "directive"
 )
 
-var TokenKind map[int]int
-var tokenDescription map[int]string
+var tokenDescription = [...]string{
+	EOF:          "EOF",
+	BANG:         "!",
+	QUESTION:     "?",
+	DOLLAR:       "$",
+	PAREN_L:      "(",
+	PAREN_R:      ")",
+	SPREAD:       "...",
+	COLON:        ":",
+	EQUALS:       "=",
+	STAR:         "*",
+	AT:           "@",
+	BRACKET_L:    "[",
+	BRACKET_R:    "]",
+	BRACE_L:      "{",
+	PIPE:         "|",
+	BRACE_R:      "}",
+	NAME:         "Name",
+	NS:           "NS",
+	INT:          "Int",
+	FLOAT:        "Float",
+	STRING:       "String",
+	BLOCK_STRING: "BlockString",
+	AMP:          "&",
+}
+
+// punctuation maps the bytes of single-byte punctuators to their kinds.
+var punctuation = [256]uint8{
+	'!': BANG,
+	'?': QUESTION,
+	'$': DOLLAR,
+	'&': AMP,
+	'(': PAREN_L,
+	')': PAREN_R,
+	'*': STAR,
+	':': COLON,
+	'=': EQUALS,
+	'@': AT,
+	'[': BRACKET_L,
+	']': BRACKET_R,
+	'{': BRACE_L,
+	'|': PIPE,
+	'}': BRACE_R,
+}
+
+// interned holds the names shared by most specs, so tokens for them do
+// not refer to the source text.
+var interned = map[string]string{}
 
 func init() {
-	TokenKind = make(map[int]int)
-	{
-		TokenKind[EOF] = EOF
-		TokenKind[BANG] = BANG
-		TokenKind[QUESTION] = QUESTION
-		TokenKind[DOLLAR] = DOLLAR
-		TokenKind[PAREN_L] = PAREN_L
-		TokenKind[PAREN_R] = PAREN_R
-		TokenKind[SPREAD] = SPREAD
-		TokenKind[COLON] = COLON
-		TokenKind[EQUALS] = EQUALS
-		TokenKind[STAR] = STAR
-		TokenKind[AT] = AT
-		TokenKind[BRACKET_L] = BRACKET_L
-		TokenKind[BRACKET_R] = BRACKET_R
-		TokenKind[BRACE_L] = BRACE_L
-		TokenKind[PIPE] = PIPE
-		TokenKind[BRACE_R] = BRACE_R
-		TokenKind[NAME] = NAME
-		TokenKind[NS] = NS
-		TokenKind[INT] = INT
-		TokenKind[FLOAT] = FLOAT
-		TokenKind[STRING] = STRING
-		TokenKind[BLOCK_STRING] = BLOCK_STRING
-	}
-	tokenDescription = make(map[int]string)
-	{
-		tokenDescription[TokenKind[EOF]] = "EOF"
-		tokenDescription[TokenKind[BANG]] = "!"
-		tokenDescription[TokenKind[QUESTION]] = "?"
-		tokenDescription[TokenKind[DOLLAR]] = "$"
-		tokenDescription[TokenKind[PAREN_L]] = "("
-		tokenDescription[TokenKind[PAREN_R]] = ")"
-		tokenDescription[TokenKind[SPREAD]] = "..."
-		tokenDescription[TokenKind[COLON]] = ":"
-		tokenDescription[TokenKind[EQUALS]] = "="
-		tokenDescription[TokenKind[STAR]] = "*"
-		tokenDescription[TokenKind[AT]] = "@"
-		tokenDescription[TokenKind[BRACKET_L]] = "["
-		tokenDescription[TokenKind[BRACKET_R]] = "]"
-		tokenDescription[TokenKind[BRACE_L]] = "{"
-		tokenDescription[TokenKind[PIPE]] = "|"
-		tokenDescription[TokenKind[BRACE_R]] = "}"
-		tokenDescription[TokenKind[NAME]] = "Name"
-		tokenDescription[TokenKind[NS]] = "NS"
-		tokenDescription[TokenKind[INT]] = "Int"
-		tokenDescription[TokenKind[FLOAT]] = "Float"
-		tokenDescription[TokenKind[STRING]] = "String"
-		tokenDescription[TokenKind[BLOCK_STRING]] = "BlockString"
-		tokenDescription[TokenKind[AMP]] = "&"
+	for _, name := range []string{
+		NAMESPACE, IMPORT, ALIAS, TYPE, FUNC, INTERFACE, UNION, ENUM, DIRECTIVE,
+		"from", "on", "require", "as", "stream", "true", "false", "void",
+		"string", "bool", "i8", "i16", "i32", "i64", "u8", "u16", "u32", "u64",
+		"f32", "f64", "bytes", "datetime", "any", "raw", "value",
+	} {
+		interned[name] = name
 	}
 }
 
@@ -132,281 +134,354 @@ type Token struct {
 	Value string
 }
 
-type Lexer func(resetPosition uint) (Token, error)
+// Lexer reads the tokens of a source in order. Token values are
+// substrings of a single copy of the source text, so most tokens are
+// read without allocating.
+type Lexer struct {
+	source *source.Source
+	body   []byte
+	text   string
+	pos    uint
+
+	// next is the token returned by Peek, if peeked is set.
+	next    Token
+	nextErr error
+	peeked  bool
+}
+
+// Lex returns a Lexer positioned at the start of s.
+func Lex(s *source.Source) *Lexer {
+	return &Lexer{
+		source: s,
+		body:   s.Body,
+		text:   string(s.Body),
+	}
+}
 
-func Lex(s *source.Source) Lexer {
-	var prevPosition uint
-	return func(resetPosition uint) (Token, error) {
-		if resetPosition == 0 {
-			resetPosition = prevPosition
+// Next returns the next token, or an EOF token at the end of the
+// source.
+func (l *Lexer) Next() (Token, error) {
+	if l.peeked {
+		l.peeked = false
+		if l.nextErr == nil {
+			l.pos = l.next.End
 		}
-		token, err := readToken(s, resetPosition)
-		if err != nil {
-			return token, err
+		return l.next, l.nextErr
+	}
+	token, err := l.read()
+	if err == nil {
+		l.pos = token.End
+	}
+	return token, err
+}
+
+// Peek returns the token Next will return, without consuming it.
+func (l *Lexer) Peek() (Token, error) {
+	if !l.peeked {
+		l.next, l.nextErr = l.read()
+		l.peeked = true
+	}
+	return l.next, l.nextErr
+}
+
+// read reads the token at the cursor without moving it.
+func (l *Lexer) read() (Token, error) {
+	body := l.body
+	position := l.skipIgnored(l.pos)
+	if position >= uint(len(body)) {
+		return Token{Kind: EOF, Start: position, End: position}, nil
+	}
+
+	c := body[position]
+	if kind := punctuation[c]; kind != 0 {
+		return Token{Kind: int(kind), Start: position, End: position + 1}, nil
+	}
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		return l.readName(position), nil
+	case c >= '0' && c <= '9', c == '-':
+		return l.readNumber(position)
+	case c == '"':
+		if l.byteAt(position+1) == '"' && l.byteAt(position+2) == '"' {
+			return l.readBlockString(position)
+		}
+		return l.readString(position)
+	case c == '.':
+		if l.byteAt(position+1) == '.' && l.byteAt(position+2) == '.' {
+			return Token{Kind: SPREAD, Start: position, End: position + 3}, nil
+		}
+	}
+
+	code, _ := runeAt(body, position)
+	if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
+		return Token{}, l.error(position, fmt.Sprintf(`Invalid character %v`, printCharCode(code)))
+	}
+	return Token{}, l.error(position, fmt.Sprintf("Unexpected character %v.", printCharCode(code)))
+}
+
+// skipIgnored returns the position of the first byte at or after
+// position that is not whitespace, a comma or part of a comment.
+func (l *Lexer) skipIgnored(position uint) uint {
+	body := l.body
+	length := uint(len(body))
+	for position < length {
+		switch body[position] {
+		case '\t', ' ', '\n', '\r', ',':
+			position++
+		case 0xEF: // BOM
+			if position+2 < length && body[position+1] == 0xBB && body[position+2] == 0xBF {
+				position += 3
+				continue
+			}
+			return position
+		case '#':
+			position++
+			// SourceCharacter but not LineTerminator
+			for position < length {
+				c := body[position]
+				if c == '\n' || c == '\r' || c < 0x20 && c != '\t' {
+					break
+				}
+				position++
+			}
+		default:
+			return position
 		}
-		prevPosition = token.End
-		return token, nil
 	}
+	return position
 }
 
 // Reads an alphanumeric + underscore name from the source.
 // [_A-Za-z][_0-9A-Za-z]*
-// position: Points to the byte position in the byte array
-// runePosition: Points to the rune position in the byte array
-func readName(source *source.Source, position, runePosition uint) Token {
-	body := source.Body
-	bodyLength := uint(len(body))
-	endByte := position + 1
-	endRune := runePosition + 1
+// Names that contain a dot are namespaces.
+func (l *Lexer) readName(start uint) Token {
+	body := l.body
+	end := start + 1
 	kind := NAME
-	for {
-		code, _ := runeAt(body, endByte)
-		if (endByte != bodyLength) &&
-			(code == '_' || code == '.' || // _
-				(code >= '0' && code <= '9') || // 0-9
-				(code >= 'A' && code <= 'Z') || // A-Z
-				(code >= 'a' && code <= 'z')) { // a-z
-			if code == '.' {
-				kind = NS
-			}
-			endByte++
-			endRune++
-			continue
-		} else {
+	for end < uint(len(body)) {
+		c := body[end]
+		if c == '.' {
+			kind = NS
+		} else if !(c == '_' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
 			break
 		}
+		end++
 	}
-	return makeToken(TokenKind[kind], runePosition, endRune, string(body[position:endByte]))
+	value, ok := interned[l.text[start:end]]
+	if !ok {
+		value = l.text[start:end]
+	}
+	return Token{Kind: kind, Start: start, End: end, Value: value}
 }
 
 // Reads a number token from the source file, either a float
 // or an int depending on whether a decimal point appears.
 // Int:   -?(0|[1-9][0-9]*)
 // Float: -?(0|[1-9][0-9]*)(\.[0-9]+)?((E|e)(+|-)?[0-9]+)?
-func readNumber(s *source.Source, start uint, firstCode rune, codeLength uint) (Token, error) {
-	code := firstCode
-	body := s.Body
+func (l *Lexer) readNumber(start uint) (Token, error) {
 	position := start
 	isFloat := false
-	if code == '-' { // -
-		position += codeLength
-		code, codeLength = runeAt(body, position)
-	}
-	if code == '0' { // 0
-		position += codeLength
-		code, codeLength = runeAt(body, position)
-		if code >= '0' && code <= '9' {
-			description := fmt.Sprintf("Invalid number, unexpected digit after 0: %v.", printCharCode(code))
-			return Token{}, errors.NewSyntaxError(s, position, description)
+	if l.byteAt(position) == '-' {
+		position++
+	}
+	if l.byteAt(position) == '0' {
+		position++
+		if c := l.byteAt(position); c >= '0' && c <= '9' {
+			description := fmt.Sprintf("Invalid number, unexpected digit after 0: %v.", printCharCode(rune(c)))
+			return Token{}, l.error(position, description)
 		}
 	} else {
-		p, err := readDigits(s, position, code, codeLength)
+		p, err := l.readDigits(position)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
-		code, codeLength = runeAt(body, position)
 	}
-	if code == '.' { // .
+	if l.byteAt(position) == '.' {
 		isFloat = true
-		position += codeLength
-		code, codeLength = runeAt(body, position)
-		p, err := readDigits(s, position, code, codeLength)
+		p, err := l.readDigits(position + 1)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
-		code, codeLength = runeAt(body, position)
 	}
-	if code == 'E' || code == 'e' { // E e
+	if c := l.byteAt(position); c == 'E' || c == 'e' {
 		isFloat = true
-		position += codeLength
-		code, codeLength = runeAt(body, position)
-		if code == '+' || code == '-' { // + -
-			position += codeLength
-			code, codeLength = runeAt(body, position)
+		position++
+		if c := l.byteAt(position); c == '+' || c == '-' {
+			position++
 		}
-		p, err := readDigits(s, position, code, codeLength)
+		p, err := l.readDigits(position)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
 	}
-	kind := TokenKind[INT]
+	kind := INT
 	if isFloat {
-		kind = TokenKind[FLOAT]
+		kind = FLOAT
 	}
-
-	return makeToken(kind, start, position, string(body[start:position])), nil
+	return Token{Kind: kind, Start: start, End: position, Value: l.text[start:position]}, nil
 }
 
 // Returns the new position in the source after reading digits.
-func readDigits(s *source.Source, start uint, firstCode rune, codeLength uint) (uint, error) {
-	body := s.Body
+func (l *Lexer) readDigits(start uint) (uint, error) {
 	position := start
-	code := firstCode
-	if code >= '0' && code <= '9' { // 0 - 9
-		for {
-			if code >= '0' && code <= '9' { // 0 - 9
-				position += codeLength
-				code, codeLength = runeAt(body, position)
-				continue
-			} else {
-				break
-			}
+	for {
+		c := l.byteAt(position)
+		if c < '0' || c > '9' {
+			break
 		}
-		return position, nil
+		position++
 	}
-	description := fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(code))
-	return position, errors.NewSyntaxError(s, position, description)
+	if position == start {
+		code, _ := runeAt(l.body, position)
+		description := fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(code))
+		return position, l.error(position, description)
+	}
+	return position, nil
 }
 
-func readString(s *source.Source, start uint) (Token, error) {
-	body := s.Body
+func (l *Lexer) readString(start uint) (Token, error) {
+	body := l.body
 	position := start + 1
-	runePosition := start + 1
 	chunkStart := position
-	var code rune
-	var n uint
-	var valueBuffer bytes.Buffer
-	for {
-		code, n = runeAt(body, position)
-		if position < uint(len(body)) &&
-			// not LineTerminator
-			code != 0x000A && code != 0x000D &&
-			// not Quote (")
-			code != '"' {
-
-			// SourceCharacter
-			if code < 0x0020 && code != 0x0009 {
-				return Token{}, errors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
-			}
-			position += n
-			runePosition++
-			if code == '\\' { // \
-				valueBuffer.Write(body[chunkStart : position-1])
-				code, n = runeAt(body, position)
-				switch code {
-				case '"':
-					valueBuffer.WriteRune('"')
-				case '/':
-					valueBuffer.WriteRune('/')
-				case '\\':
-					valueBuffer.WriteRune('\\')
-				case 'b':
-					valueBuffer.WriteRune('\b')
-				case 'f':
-					valueBuffer.WriteRune('\f')
-				case 'n':
-					valueBuffer.WriteRune('\n')
-				case 'r':
-					valueBuffer.WriteRune('\r')
-				case 't':
-					valueBuffer.WriteRune('\t')
-				case 'u':
-					// Check if there are at least 4 bytes available
-					if uint(len(body)) <= position+4 {
-						return Token{}, errors.NewSyntaxError(s, runePosition,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", string(body[position+1:])))
-					}
-					charCode := uniCharCode(
-						rune(body[position+1]),
-						rune(body[position+2]),
-						rune(body[position+3]),
-						rune(body[position+4]),
-					)
-					if charCode < 0 {
-						return Token{}, errors.NewSyntaxError(s, runePosition,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", string(body[position+1:position+5])))
-					}
-					valueBuffer.WriteRune(charCode)
-					position += 4
-					runePosition += 4
-				default:
-					return Token{}, errors.NewSyntaxError(s, runePosition,
-						fmt.Sprintf(`Invalid character escape sequence: \\%c.`, code))
-				}
-				position += n
-				runePosition++
-				chunkStart = position
+	// value is only allocated for strings with escape sequences.
+	var value []byte
+	for position < uint(len(body)) {
+		c := body[position]
+		// LineTerminator or Quote (")
+		if c == '\n' || c == '\r' || c == '"' {
+			break
+		}
+		// SourceCharacter
+		if c < 0x0020 && c != 0x0009 {
+			return Token{}, l.error(position, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(rune(c))))
+		}
+		if c >= utf8.RuneSelf {
+			code, n := utf8.DecodeRune(body[position:])
+			if code == utf8.RuneError && n == 1 {
+				return Token{}, l.error(position, "Invalid UTF-8 within String.")
 			}
+			position += uint(n)
 			continue
-		} else {
-			break
 		}
+		if c != '\\' {
+			position++
+			continue
+		}
+
+		value = append(value, body[chunkStart:position]...)
+		code, n := runeAt(body, position+1)
+		switch code {
+		case '"':
+			value = append(value, '"')
+		case '/':
+			value = append(value, '/')
+		case '\\':
+			value = append(value, '\\')
+		case 'b':
+			value = append(value, '\b')
+		case 'f':
+			value = append(value, '\f')
+		case 'n':
+			value = append(value, '\n')
+		case 'r':
+			value = append(value, '\r')
+		case 't':
+			value = append(value, '\t')
+		case 'u':
+			// Check if there are at least 4 bytes available
+			if uint(len(body)) <= position+5 {
+				return Token{}, l.error(position+1, fmt.Sprintf("Invalid character escape sequence: "+
+					"\\u%v", string(body[position+2:])))
+			}
+			charCode := uniCharCode(
+				rune(body[position+2]),
+				rune(body[position+3]),
+				rune(body[position+4]),
+				rune(body[position+5]),
+			)
+			if charCode < 0 {
+				return Token{}, l.error(position+1, fmt.Sprintf("Invalid character escape sequence: "+
+					"\\u%v", string(body[position+2:position+6])))
+			}
+			value = utf8.AppendRune(value, charCode)
+			position += 4
+		default:
+			return Token{}, l.error(position+1, fmt.Sprintf(`Invalid character escape sequence: \\%c.`, code))
+		}
+		position += 1 + n
+		chunkStart = position
+	}
+	if l.byteAt(position) != '"' {
+		return Token{}, l.error(position, "Unterminated string.")
 	}
-	if code != '"' { // quote (")
-		return Token{}, errors.NewSyntaxError(s, runePosition, "Unterminated string.")
+	if value == nil {
+		return Token{Kind: STRING, Start: start, End: position + 1, Value: l.text[chunkStart:position]}, nil
 	}
-	stringContent := body[chunkStart:position]
-	valueBuffer.Write(stringContent)
-	value := valueBuffer.String()
-	return makeToken(TokenKind[STRING], start, position+1, value), nil
+	value = append(value, body[chunkStart:position]...)
+	return Token{Kind: STRING, Start: start, End: position + 1, Value: string(value)}, nil
 }
 
 // readBlockString reads a block string token from the source file.
 //
 // """("?"?(\\"""|\\(?!=""")|[^"\\]))*"""
-func readBlockString(s *source.Source, start uint) (Token, error) {
-	body := s.Body
+func (l *Lexer) readBlockString(start uint) (Token, error) {
+	body := l.body
 	position := start + 3
-	runePosition := start + 3
 	chunkStart := position
-	var valueBuffer bytes.Buffer
-
-	for {
-		// Stop if we've reached the end of the buffer
-		if position >= uint(len(body)) {
-			break
-		}
+	var raw strings.Builder
 
-		code, n := runeAt(body, position)
+	for position < uint(len(body)) {
+		c := body[position]
 
 		// Closing Triple-Quote (""")
-		if code == '"' {
-			x, _ := runeAt(body, position+1)
-			y, _ := runeAt(body, position+2)
-			if x == '"' && y == '"' {
-				stringContent := body[chunkStart:position]
-				valueBuffer.Write(stringContent)
-				value := blockStringValue(valueBuffer.String())
-				return makeToken(TokenKind[BLOCK_STRING], start, position+3, value), nil
-			}
+		if c == '"' && l.byteAt(position+1) == '"' && l.byteAt(position+2) == '"' {
+			raw.WriteString(l.text[chunkStart:position])
+			value := blockStringValue(raw.String())
+			return Token{Kind: BLOCK_STRING, Start: start, End: position + 3, Value: value}, nil
 		}
 
 		// SourceCharacter
-		if code < 0x0020 &&
-			code != 0x0009 &&
-			code != 0x000a &&
-			code != 0x000d {
-			return Token{}, errors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
+		if c < 0x0020 && c != 0x0009 && c != 0x000a && c != 0x000d {
+			return Token{}, l.error(position, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(rune(c))))
+		}
+		if c >= utf8.RuneSelf {
+			code, n := utf8.DecodeRune(body[position:])
+			if code == utf8.RuneError && n == 1 {
+				return Token{}, l.error(position, "Invalid UTF-8 within String.")
+			}
+			position += uint(n)
+			continue
 		}
 
 		// Escape Triple-Quote (\""")
-		if code == '\\' { // \
-			x, _ := runeAt(body, position+1)
-			y, _ := runeAt(body, position+2)
-			z, _ := runeAt(body, position+3)
-			if x == '"' && y == '"' && z == '"' {
-				stringContent := append(body[chunkStart:position], []byte(`"""`)...)
-				valueBuffer.Write(stringContent)
-				position += 4     // account for `"""` characters
-				runePosition += 4 // "       "   "     "
-				chunkStart = position
-				continue
-			}
+		if c == '\\' && l.byteAt(position+1) == '"' && l.byteAt(position+2) == '"' && l.byteAt(position+3) == '"' {
+			raw.WriteString(l.text[chunkStart:position])
+			raw.WriteString(`"""`)
+			position += 4
+			chunkStart = position
+			continue
 		}
 
-		position += n
-		runePosition++
+		position++
 	}
 
-	return Token{}, errors.NewSyntaxError(s, runePosition, "Unterminated string.")
+	return Token{}, l.error(position, "Unterminated string.")
 }
 
-var splitLinesRegex = regexp.MustCompile("\r\n|[\n\r]")
+func (l *Lexer) error(position uint, description string) error {
+	return errors.NewSyntaxError(l.source, position, description)
+}
+
+// byteAt returns the byte at position, or 0 at the end of the source.
+func (l *Lexer) byteAt(position uint) byte {
+	if position < uint(len(l.body)) {
+		return l.body[position]
+	}
+	return 0
+}
 
 // This implements the GraphQL spec's BlockStringValue() static algorithm.
 //
@@ -417,7 +492,7 @@ var splitLinesRegex = regexp.MustCompile("\r\n|[\n\r]")
 // Heavily borrows from: https://github.com/graphql/graphql-js/blob/8e0c599ceccfa8c40d6edf3b72ee2a71490b10e0/src/language/blockStringValue.js
 func blockStringValue(in string) string {
 	// Expand a block string's raw value into independent lines.
-	lines := splitLinesRegex.Split(in, -1)
+	lines := splitLines(in)
 
 	// Remove common indentation from all lines but first
 	commonIndent := -1
@@ -441,34 +516,43 @@ func blockStringValue(in string) string {
 	}
 
 	// Remove leading blank lines.
-	for {
-		if isBlank := lineIsBlank(lines[0]); !isBlank {
-			break
-		}
+	for len(lines) > 0 && lineIsBlank(lines[0]) {
 		lines = lines[1:]
 	}
 
 	// Remove trailing blank lines.
-	for {
-		i := len(lines) - 1
-		if isBlank := lineIsBlank(lines[i]); !isBlank {
-			break
-		}
-		lines = append(lines[:i], lines[i+1:]...)
+	for len(lines) > 0 && lineIsBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
 	}
 
 	// Return a string of the lines joined with U+000A.
 	return strings.Join(lines, "\n")
 }
 
+// splitLines splits in at each "\r\n", "\n" or "\r".
+func splitLines(in string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(in); i++ {
+		switch in[i] {
+		case '\r':
+			lines = append(lines, in[start:i])
+			if i+1 < len(in) && in[i+1] == '\n' {
+				i++
+			}
+			start = i + 1
+		case '\n':
+			lines = append(lines, in[start:i])
+			start = i + 1
+		}
+	}
+	return append(lines, in[start:])
+}
+
 // leadingWhitespaceLen returns count of whitespace characters on given line.
 func leadingWhitespaceLen(in string) (n int) {
-	for _, ch := range in {
-		if ch == ' ' || ch == '\t' {
-			n++
-		} else {
-			break
-		}
+	for n < len(in) && (in[n] == ' ' || in[n] == '\t') {
+		n++
 	}
 	return
 }
@@ -505,10 +589,6 @@ func char2hex(a rune) int {
 	return -1
 }
 
-func makeToken(kind int, start uint, end uint, value string) Token {
-	return Token{Kind: kind, Start: start, End: end, Value: value}
-}
-
 func printCharCode(code rune) string {
 	// NaN/undefined represents access beyond the end of the file.
 	if code < 0 {
@@ -522,110 +602,11 @@ func printCharCode(code rune) string {
 	return fmt.Sprintf(`"\\u%04X"`, code)
 }
 
-func readToken(s *source.Source, fromPosition uint) (Token, error) {
-	body := s.Body
-	bodyLength := uint(len(body))
-	position, runePosition := positionAfterWhitespace(body, fromPosition)
-	if position >= bodyLength {
-		return makeToken(TokenKind[EOF], position, position, ""), nil
-	}
-	code, codeLength := runeAt(body, position)
-
-	// SourceCharacter
-	if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
-		return Token{}, errors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character %v`, printCharCode(code)))
-	}
-
-	switch code {
-	// !
-	case '!':
-		return makeToken(TokenKind[BANG], position, position+1, ""), nil
-	case '?':
-		return makeToken(TokenKind[QUESTION], position, position+1, ""), nil
-	// $
-	case '$':
-		return makeToken(TokenKind[DOLLAR], position, position+1, ""), nil
-	// &
-	case '&':
-		return makeToken(TokenKind[AMP], position, position+1, ""), nil
-	// (
-	case '(':
-		return makeToken(TokenKind[PAREN_L], position, position+1, ""), nil
-	// )
-	case ')':
-		return makeToken(TokenKind[PAREN_R], position, position+1, ""), nil
-	case '*':
-		return makeToken(TokenKind[STAR], position, position+1, ""), nil
-	// .
-	case '.':
-		next1, _ := runeAt(body, position+1)
-		next2, _ := runeAt(body, position+2)
-		if next1 == '.' && next2 == '.' {
-			return makeToken(TokenKind[SPREAD], position, position+3, ""), nil
-		}
-	// :
-	case ':':
-		return makeToken(TokenKind[COLON], position, position+1, ""), nil
-	// =
-	case '=':
-		return makeToken(TokenKind[EQUALS], position, position+1, ""), nil
-	// @
-	case '@':
-		return makeToken(TokenKind[AT], position, position+1, ""), nil
-	// [
-	case '[':
-		return makeToken(TokenKind[BRACKET_L], position, position+1, ""), nil
-	// ]
-	case ']':
-		return makeToken(TokenKind[BRACKET_R], position, position+1, ""), nil
-	// {
-	case '{':
-		return makeToken(TokenKind[BRACE_L], position, position+1, ""), nil
-	// |
-	case '|':
-		return makeToken(TokenKind[PIPE], position, position+1, ""), nil
-	// }
-	case '}':
-		return makeToken(TokenKind[BRACE_R], position, position+1, ""), nil
-	// A-Z
-	case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N',
-		'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-		return readName(s, position, runePosition), nil
-	// _
-	// a-z
-	case '_', 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n',
-		'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
-		return readName(s, position, runePosition), nil
-	// -
-	// 0-9
-	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-		token, err := readNumber(s, position, code, codeLength)
-		if err != nil {
-			return token, err
-		}
-		return token, nil
-	// "
-	case '"':
-		var token Token
-		var err error
-		x, _ := runeAt(body, position+1)
-		y, _ := runeAt(body, position+2)
-		if x == '"' && y == '"' {
-			token, err = readBlockString(s, position)
-		} else {
-			token, err = readString(s, position)
-		}
-		return token, err
-	}
-	description := fmt.Sprintf("Unexpected character %v.", printCharCode(code))
-	return Token{}, errors.NewSyntaxError(s, runePosition, description)
-}
-
 // Gets the rune from the byte array at given byte position and it's width in bytes
 func runeAt(body []byte, position uint) (code rune, charWidth uint) {
 	if uint(len(body)) <= position {
 		// <EOF>
-		return -1, utf8.RuneError
+		return -1, 0
 	}
 
 	c := body[position]
@@ -637,57 +618,6 @@ func runeAt(body []byte, position uint) (code rune, charWidth uint) {
 	return r, uint(n)
 }
 
-// Reads from body starting at startPosition until it finds a non-whitespace
-// or commented character, then returns the position of that character for lexing.
-// lexing.
-// Returns both byte positions and rune position
-func positionAfterWhitespace(body []byte, startPosition uint) (position uint, runePosition uint) {
-	bodyLength := uint(len(body))
-	position = startPosition
-	runePosition = startPosition
-	for {
-		if position < bodyLength {
-			code, n := runeAt(body, position)
-
-			// Skip Ignored
-			if code == 0xFEFF || // BOM
-				// White Space
-				code == 0x0009 || // tab
-				code == 0x0020 || // space
-				// Line Terminator
-				code == 0x000A || // new line
-				code == 0x000D || // carriage return
-				// Comma
-				code == 0x002C {
-				position += n
-				runePosition++
-			} else if code == 35 { // #
-				position += n
-				runePosition++
-				for {
-					code, n := runeAt(body, position)
-					if position < bodyLength &&
-						code != 0 &&
-						// SourceCharacter but not LineTerminator
-						(code > 0x001F || code == 0x0009) && code != 0x000A && code != 0x000D {
-						position += n
-						runePosition++
-						continue
-					} else {
-						break
-					}
-				}
-			} else {
-				break
-			}
-			continue
-		} else {
-			break
-		}
-	}
-	return position, runePosition
-}
-
 func GetTokenDesc(token Token) string {
 	if token.Value == "" {
 		return GetTokenKindDesc(token.Kind)
@@ -696,5 +626,8 @@ func GetTokenDesc(token Token) string {
 }
 
 func GetTokenKindDesc(kind int) string {
+	if kind < 0 || kind >= len(tokenDescription) {
+		return ""
+	}
 	return tokenDescription[kind]
 }
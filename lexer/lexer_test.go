@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lexer
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/apexlang/apex-go/internal/testspecs"
+	"github.com/apexlang/apex-go/source"
+)
+
+func FuzzLex(f *testing.F) {
+	for _, b := range testspecs.Load(f) {
+		f.Add(b)
+	}
+	f.Add([]byte(`"""\""""""`))
+	f.Add([]byte("é name"))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		original := append([]byte(nil), body...)
+		lex := Lex(source.NewSource("fuzz", body))
+		var prev uint
+		for {
+			token, err := lex.Next()
+			if err != nil {
+				break
+			}
+			if token.Start < prev || token.End < token.Start || token.End > uint(len(body)) {
+				t.Fatalf("token %q spans %d:%d after %d in %d bytes",
+					GetTokenDesc(token), token.Start, token.End, prev, len(body))
+			}
+			if token.Kind == EOF {
+				break
+			}
+			if token.End == prev {
+				t.Fatalf("token %q does not advance past %d", GetTokenDesc(token), prev)
+			}
+			prev = token.End
+		}
+		if !bytes.Equal(body, original) {
+			t.Fatalf("lexing modified the source")
+		}
+	})
+}
+
+// largeSpec returns model.axdl repeated to about 330KB.
+func largeSpec(b *testing.B) []byte {
+	body, err := os.ReadFile("../model.axdl")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return bytes.Repeat(body, 50)
+}
+
+func BenchmarkLex(b *testing.B) {
+	body := largeSpec(b)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lex := Lex(source.NewSource("large.axdl", body))
+		for {
+			token, err := lex.Next()
+			if err != nil {
+				b.Fatal(err)
+			}
+			if token.Kind == EOF {
+				break
+			}
+		}
+	}
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package location
 
 import (
-	"regexp"
-
 	"github.com/apexlang/apex-go/source"
 )
 
@@ -27,25 +25,29 @@ type SourceLocation struct {
 	Column uint `json:"column"`
 }
 
+// GetLocation returns the line and column, both starting at 1, of the
+// byte at position in s. Columns are counted in bytes.
 func GetLocation(s *source.Source, position uint) SourceLocation {
-	body := []byte{}
-	if s != nil {
-		body = s.Body
+	return GetLocationIn(s, position, source.Bytes)
+}
+
+// GetLocationIn is GetLocation with columns counted in unit.
+func GetLocationIn(s *source.Source, position uint, unit source.Unit) SourceLocation {
+	if s == nil {
+		return SourceLocation{Line: 1, Column: position + 1}
 	}
-	line := uint(1)
-	column := position + 1
-	lineRegexp := regexp.MustCompile("\r\n|[\n\r]")
-	matches := lineRegexp.FindAllIndex(body, -1)
-	for _, match := range matches {
-		matchIndex := uint(match[0])
-		if matchIndex < position {
-			line++
-			l := uint(len(s.Body[match[0]:match[1]]))
-			column = position + 1 - (matchIndex + l)
-			continue
-		} else {
-			break
+	line, column := s.Location(position, unit)
+	return SourceLocation{Line: line, Column: column}
+}
+
+// GetPosition returns the byte offset in s of l, with the column of l
+// counted in unit. It is the inverse of GetLocationIn.
+func GetPosition(s *source.Source, l SourceLocation, unit source.Unit) uint {
+	if s == nil {
+		if l.Column < 1 {
+			return 0
 		}
+		return l.Column - 1
 	}
-	return SourceLocation{Line: line, Column: column}
+	return s.Offset(l.Line, l.Column, unit)
 }
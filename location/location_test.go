@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package location
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/apexlang/apex-go/source"
+)
+
+func BenchmarkGetLocation(b *testing.B) {
+	body, err := os.ReadFile("../model.axdl")
+	if err != nil {
+		b.Fatal(err)
+	}
+	body = bytes.Repeat(body, 50)
+	s := source.NewSource("large.axdl", body)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GetLocation(s, uint(i*7919%len(body)))
+	}
+}
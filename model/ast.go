@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// ToAST rebuilds the document of ns, the reverse of Convert. The
+// definitions are grouped by kind in the order Convert reads them, and
+// have no locations. Converting the result returns a namespace equal to
+// ns.
+func ToAST(ns *Namespace) *ast.Document {
+	definitions := []ast.Node{
+		ast.NewNamespaceDefinition(nil, ast.Ident(ns.Name), stringNode(ns.Description), annotationNodes(ns.Annotations)),
+	}
+	for _, item := range ns.Imports {
+		definitions = append(definitions, importNode(item))
+	}
+	for _, item := range ns.Directives {
+		definitions = append(definitions, directiveNode(item))
+	}
+	for _, item := range ns.Aliases {
+		definitions = append(definitions, ast.NewAliasDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+			typeNode(item.Type), annotationNodes(item.Annotations)))
+	}
+	for _, item := range ns.Functions {
+		definitions = append(definitions, operationNode(item))
+	}
+	for _, item := range ns.Interfaces {
+		operations := make([]*ast.OperationDefinition, len(item.Operations))
+		for i, operation := range item.Operations {
+			operations[i] = operationNode(operation)
+		}
+		definitions = append(definitions, ast.NewInterfaceDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+			annotationNodes(item.Annotations), operations))
+	}
+	for _, item := range ns.Types {
+		fields := make([]*ast.FieldDefinition, len(item.Fields))
+		for i, field := range item.Fields {
+			fields[i] = ast.NewFieldDefinition(nil, ast.Ident(field.Name), stringNode(field.Description),
+				typeNode(field.Type), valueNodePtr(field.DefaultValue), annotationNodes(field.Annotations))
+		}
+		definitions = append(definitions, ast.NewTypeDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+			nil, annotationNodes(item.Annotations), fields))
+	}
+	for _, item := range ns.Enums {
+		values := make([]*ast.EnumValueDefinition, len(item.Values))
+		for i, value := range item.Values {
+			values[i] = ast.NewEnumValueDefinition(nil, ast.Ident(value.Name), stringNode(value.Description),
+				ast.NewIntValue(nil, int(value.Index)), stringNode(value.Display), annotationNodes(value.Annotations))
+		}
+		definitions = append(definitions, ast.NewEnumDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+			annotationNodes(item.Annotations), values))
+	}
+	for _, item := range ns.Unions {
+		types := make([]ast.Type, len(item.Types))
+		for i, t := range item.Types {
+			types[i] = typeNode(t)
+		}
+		definitions = append(definitions, ast.NewUnionDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+			annotationNodes(item.Annotations), types))
+	}
+	return ast.NewDocument(nil, definitions)
+}
+
+func importNode(item Import) *ast.ImportDefinition {
+	var names []*ast.ImportName
+	for _, name := range item.Names {
+		var alias *ast.Name
+		if name.As != nil {
+			alias = ast.Ident(*name.As)
+		}
+		names = append(names, ast.NewImportName(nil, ast.Ident(name.Name), alias))
+	}
+	return ast.NewImportDefinition(nil, stringNode(item.Description), item.All, names, ast.Str(item.From),
+		annotationNodes(item.Annotations))
+}
+
+func directiveNode(item Directive) *ast.DirectiveDefinition {
+	var requires []*ast.DirectiveRequire
+	for _, require := range item.Require {
+		requires = append(requires, ast.NewDirectiveRequire(nil, ast.Ident(require.Directive), locationNodes(require.Locations)))
+	}
+	return ast.NewDirectiveDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+		parameterNodes(item.Parameters), locationNodes(item.Locations), requires)
+}
+
+func locationNodes(items []DirectiveLocation) []*ast.Name {
+	var s []*ast.Name
+	for _, item := range items {
+		s = append(s, ast.Ident(item.String()))
+	}
+	return s
+}
+
+// operationNode returns the operation of item. A unary operation has its
+// parameter as the only one, and an operation without a return type
+// returns void as it does in a spec.
+func operationNode(item Operation) *ast.OperationDefinition {
+	parameters := parameterNodes(item.Parameters)
+	if item.Unary != nil {
+		parameters = parameterNodes([]Parameter{*item.Unary})
+	}
+	var returns ast.Type = ast.TypeName("void")
+	if item.Returns != nil {
+		returns = typeNode(*item.Returns)
+	}
+	return ast.NewOperationDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+		returns, annotationNodes(item.Annotations), item.Unary != nil, parameters)
+}
+
+func parameterNodes(items []Parameter) []*ast.ParameterDefinition {
+	var s []*ast.ParameterDefinition
+	for _, item := range items {
+		s = append(s, ast.NewParameterDefinition(nil, ast.Ident(item.Name), stringNode(item.Description),
+			typeNode(item.Type), valueNodePtr(item.DefaultValue), annotationNodes(item.Annotations)))
+	}
+	return s
+}
+
+func typeNode(t TypeRef) ast.Type {
+	switch {
+	case t.Scalar != nil:
+		return ast.TypeName(strings.ToLower(t.Scalar.String()))
+	case t.Named != nil:
+		return ast.TypeName(t.Named.Name)
+	case t.List != nil:
+		return ast.ListOf(typeNode(t.List.Type))
+	case t.Map != nil:
+		return ast.MapOf(typeNode(t.Map.KeyType), typeNode(t.Map.ValueType))
+	case t.Optional != nil:
+		return ast.OptionalOf(typeNode(t.Optional.Type))
+	case t.Stream != nil:
+		return ast.StreamOf(typeNode(t.Stream.Type))
+	}
+	return nil
+}
+
+func annotationNodes(items []Annotation) []*ast.Annotation {
+	var s []*ast.Annotation
+	for _, item := range items {
+		var arguments []*ast.Argument
+		for _, argument := range item.Arguments {
+			arguments = append(arguments, ast.NewArgument(nil, ast.Ident(argument.Name), valueNode(argument.Value)))
+		}
+		s = append(s, ast.Annotate(item.Name, arguments...))
+	}
+	return s
+}
+
+func valueNodePtr(v *Value) ast.Value {
+	if v == nil {
+		return nil
+	}
+	return valueNode(*v)
+}
+
+func valueNode(v Value) ast.Value {
+	switch {
+	case v.Bool != nil:
+		return ast.NewBooleanValue(nil, *v.Bool)
+	case v.String != nil:
+		return ast.Str(*v.String)
+	case v.I64 != nil:
+		return ast.NewIntValue(nil, int(*v.I64))
+	case v.F64 != nil:
+		return ast.NewFloatValue(nil, *v.F64)
+	case v.Reference != nil:
+		return ast.NewEnumValue(nil, v.Reference.Name)
+	case v.ListValue != nil:
+		values := make([]ast.Value, len(v.ListValue.Values))
+		for i, value := range v.ListValue.Values {
+			values[i] = valueNode(value)
+		}
+		return ast.NewListValue(nil, values)
+	case v.ObjectValue != nil:
+		fields := make([]*ast.ObjectField, len(v.ObjectValue.Fields))
+		for i, field := range v.ObjectValue.Fields {
+			fields[i] = ast.NewObjectField(nil, ast.Ident(field.Name), valueNode(field.Value))
+		}
+		return ast.NewObjectValue(nil, fields)
+	}
+	return nil
+}
+
+func stringNode(value *string) *ast.StringValue {
+	if value == nil {
+		return nil
+	}
+	return ast.Str(*value)
+}
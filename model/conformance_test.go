@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected results of the conformance suite")
+
+// TestConformance runs the cases in ../conformance, which is described
+// in ../conformance/README.md.
+func TestConformance(t *testing.T) {
+	inputs, err := filepath.Glob("../conformance/*/*/input.apex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no conformance cases found")
+	}
+	for _, input := range inputs {
+		dir := filepath.Dir(input)
+		name, err := filepath.Rel("../conformance", dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			result, err := conform(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkFixes(t, dir)
+			actual, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual = append(actual, '\n')
+
+			golden := filepath.Join(dir, "expected.json")
+			if *update {
+				if err := os.WriteFile(golden, actual, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(actual, expected) {
+				t.Errorf("result differs from %s\nexpected:\n%s\nactual:\n%s", golden, expected, actual)
+			}
+			if result.Namespace != nil {
+				checkASTRoundTrip(t, dir, result.Namespace)
+				checkToAST(t, result.Namespace)
+			}
+		})
+	}
+}
+
+// checkFixes checks that applying the fixes of the errors of the case
+// in dir results in fixed.apex, or that there are none if it does not
+// exist.
+func checkFixes(t *testing.T, dir string) {
+	t.Helper()
+	fixed, ok, err := fixCase(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join(dir, "fixed.apex")
+	if *update {
+		if ok {
+			err = os.WriteFile(golden, fixed, 0o644)
+		} else if err = os.Remove(golden); os.IsNotExist(err) {
+			err = nil
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(golden)
+	if os.IsNotExist(err) {
+		if ok {
+			t.Errorf("errors have fixes but %s does not exist (run with -update to create it)", golden)
+		}
+		return
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("errors have no fixes but %s exists", golden)
+	} else if !bytes.Equal(fixed, expected) {
+		t.Errorf("fixed spec differs from %s\nexpected:\n%s\nactual:\n%s", golden, expected, fixed)
+	}
+}
+
+// fixCase returns the input of the case in dir with the fixes of its
+// validation errors applied, skipping those that overlap an earlier
+// fix. It returns false if there are no fixes.
+func fixCase(dir string) ([]byte, bool, error) {
+	doc, err := parseCase(dir)
+	if _, ok := diagnostics(err); ok {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	caseRules, err := profileRules(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	var edits []source.TextEdit
+	for _, err := range rules.Validate(doc, caseRules...) {
+		e, ok := err.(*errors.Error)
+		if !ok || len(e.Edits) == 0 || source.Overlaps(edits, e.Edits) {
+			continue
+		}
+		edits = append(edits, e.Edits...)
+	}
+	if len(edits) == 0 {
+		return nil, false, nil
+	}
+	fixed, err := source.ApplyEdits(doc.Loc.Source.Body, edits)
+	return fixed, err == nil, err
+}
+
+// checkASTRoundTrip checks that the document of the case in dir, read
+// back from JSON, still validates and converts to ns.
+func checkASTRoundTrip(t *testing.T, dir string, ns *Namespace) {
+	t.Helper()
+	doc, err := parseCase(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ast.Document
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if errs := rules.Validate(&decoded, rules.Rules...); len(errs) > 0 {
+		t.Fatalf("document read from JSON is invalid: %v", errs)
+	}
+	converted, errs := Convert(&decoded)
+	if len(errs) > 0 {
+		t.Fatalf("document read from JSON does not convert: %v", errs)
+	}
+	expected, _ := json.Marshal(ns)
+	actual, _ := json.Marshal(converted)
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("document read from JSON converts to\n%s\nwant\n%s", actual, expected)
+	}
+}
+
+// checkToAST checks that the document rebuilt from ns validates and
+// converts back to ns.
+func checkToAST(t *testing.T, ns *Namespace) {
+	t.Helper()
+	doc := ToAST(ns)
+	if errs := rules.Validate(doc, rules.Rules...); len(errs) > 0 {
+		t.Fatalf("document rebuilt from the namespace is invalid: %v", errs)
+	}
+	converted, errs := Convert(doc)
+	if len(errs) > 0 {
+		t.Fatalf("document rebuilt from the namespace does not convert: %v", errs)
+	}
+	expected, _ := json.Marshal(ns)
+	actual, _ := json.Marshal(converted)
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("document rebuilt from the namespace converts to\n%s\nwant\n%s", actual, expected)
+	}
+}
+
+// parseCase parses the input of the case in dir.
+func parseCase(dir string) (*ast.Document, error) {
+	body, err := os.ReadFile(filepath.Join(dir, "input.apex"))
+	if err != nil {
+		return nil, err
+	}
+	resolver := func(location string, from string) (string, error) {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(location)))
+		return string(b), err
+	}
+	return parser.Parse(parser.ParseParams{
+		Source: source.NewSource("input.apex", body),
+		Options: parser.ParseOptions{
+			Resolver: resolver,
+		},
+	})
+}
+
+// conform parses, validates and converts the input of the case in dir.
+// Errors in the spec are returned as the errors of the result.
+func conform(dir string) (*ParserResult, error) {
+	doc, err := parseCase(dir)
+	if err != nil {
+		errs, ok := diagnostics(err)
+		if !ok {
+			return nil, err
+		}
+		return &ParserResult{Errors: summarize(errs)}, nil
+	}
+	caseRules, err := profileRules(dir)
+	if err != nil {
+		return nil, err
+	}
+	if errs := rules.Validate(doc, caseRules...); len(errs) > 0 {
+		return &ParserResult{Errors: summarize(convertErrors(errs))}, nil
+	}
+	ns, errs := Convert(doc)
+	if len(errs) > 0 {
+		return &ParserResult{Errors: summarize(convertErrors(errs))}, nil
+	}
+	return &ParserResult{Namespace: ns}, nil
+}
+
+// profileRules returns the rules of the profile in profile.json of the
+// case in dir, or rules.Rules if there is none.
+func profileRules(dir string) ([]rules.ValidationRule, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "profile.json"))
+	if os.IsNotExist(err) {
+		return rules.Rules, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var p struct {
+		Targets []string `json:"targets"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	var profile rules.Profile
+	for _, key := range p.Targets {
+		target, ok := rules.Targets[key]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown target %q", dir, key)
+		}
+		profile.Targets = append(profile.Targets, target)
+	}
+	return profile.Rules(), nil
+}
+
+// summarize keeps the first line of each message, dropping the source
+// excerpt of syntax errors.
+func summarize(errs []Error) []Error {
+	for i := range errs {
+		errs[i].Message = strings.SplitN(errs[i].Message, "\n", 2)[0]
+	}
+	return errs
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apexlang/apex-go/parser"
+)
+
+func TestConvertContext(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `namespace "test"
+type A { a: string }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ns, errs := ConvertContext(ctx, doc)
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("ConvertContext() with a canceled context = %v, want only %v", errs, context.Canceled)
+	}
+	if ns != nil && len(ns.Types) > 0 {
+		t.Errorf("ConvertContext() with a canceled context converted %d types", len(ns.Types))
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"context"
 	"errors"
 
 	"github.com/apexlang/apex-go/ast"
@@ -27,6 +28,13 @@ func Convert(doc *ast.Document) (*Namespace, []error) {
 	return converter.Convert(doc)
 }
 
+// ConvertContext is like Convert but stops with ctx.Err() once ctx is
+// done, checking it before each definition.
+func ConvertContext(ctx context.Context, doc *ast.Document) (*Namespace, []error) {
+	var converter Converter
+	return converter.ConvertContext(ctx, doc)
+}
+
 var scalars = map[string]Scalar{
 	"string":   ScalarString,
 	"bool":     ScalarBool,
@@ -58,13 +66,22 @@ type Converter struct {
 	_interfaces []*ast.InterfaceDefinition
 
 	named map[string]Named
+	ctx   context.Context
 
 	errors []error
 }
 
 func (c *Converter) Convert(doc *ast.Document) (*Namespace, []error) {
+	return c.ConvertContext(context.Background(), doc)
+}
+
+func (c *Converter) ConvertContext(ctx context.Context, doc *ast.Document) (*Namespace, []error) {
+	c.ctx = ctx
 	c.named = make(map[string]Named)
 	for _, def := range doc.Definitions {
+		if c.done() {
+			break
+		}
 		switch t := def.(type) {
 		case *ast.NamespaceDefinition:
 			c._ns = t
@@ -103,6 +120,9 @@ func (c *Converter) Convert(doc *ast.Document) (*Namespace, []error) {
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, []error{err}
+	}
 	if c._ns == nil {
 		return nil, []error{errors.New("no namespace found")}
 	}
@@ -117,9 +137,13 @@ func (c *Converter) Convert(doc *ast.Document) (*Namespace, []error) {
 		Unions:      c.convertUnions(c._unions),
 		Functions:   c.convertOperations(c._functions),
 		Types:       c.convertTypes(c._types),
+		Enums:       c.convertEnums(c._enums),
 		Interfaces:  c.convertInterfaces(c._interfaces),
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, []error{err}
+	}
 	if len(c.errors) > 0 {
 		return nil, c.errors
 	}
@@ -127,12 +151,21 @@ func (c *Converter) Convert(doc *ast.Document) (*Namespace, []error) {
 	return &ns, nil
 }
 
+// done reports whether the context is done, in which case the
+// conversion stops.
+func (c *Converter) done() bool {
+	return c.ctx != nil && c.ctx.Err() != nil
+}
+
 func (c *Converter) convertInterfaces(items []*ast.InterfaceDefinition) []Interface {
 	if len(items) == 0 {
 		return nil
 	}
 	s := make([]Interface, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		s[i] = Interface{
 			Description: stringValuePtr(item.Description),
 			Name:        item.Name.Value,
@@ -149,6 +182,9 @@ func (c *Converter) convertTypes(items []*ast.TypeDefinition) []Type {
 	}
 	s := make([]Type, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		s[i] = Type{
 			Description: stringValuePtr(item.Description),
 			Name:        item.Name.Value,
@@ -159,6 +195,42 @@ func (c *Converter) convertTypes(items []*ast.TypeDefinition) []Type {
 	return s
 }
 
+func (c *Converter) convertEnums(items []*ast.EnumDefinition) []Enum {
+	if len(items) == 0 {
+		return nil
+	}
+	s := make([]Enum, len(items))
+	for i, item := range items {
+		if c.done() {
+			break
+		}
+		s[i] = Enum{
+			Description: stringValuePtr(item.Description),
+			Name:        item.Name.Value,
+			Values:      c.convertEnumValues(item.Values),
+			Annotations: c.convertAnnotations(item.Annotations),
+		}
+	}
+	return s
+}
+
+func (c *Converter) convertEnumValues(items []*ast.EnumValueDefinition) []EnumValue {
+	if len(items) == 0 {
+		return nil
+	}
+	s := make([]EnumValue, len(items))
+	for i, item := range items {
+		s[i] = EnumValue{
+			Description: stringValuePtr(item.Description),
+			Name:        item.Name.Value,
+			Index:       uint64(item.Index.Value),
+			Display:     stringValuePtr(item.Display),
+			Annotations: c.convertAnnotations(item.Annotations),
+		}
+	}
+	return s
+}
+
 func (c *Converter) convertFields(items []*ast.FieldDefinition) []Field {
 	if len(items) == 0 {
 		return nil
@@ -182,6 +254,9 @@ func (c *Converter) convertOperations(items []*ast.OperationDefinition) []Operat
 	}
 	s := make([]Operation, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		parameters := c.convertParameters(item.Parameters)
 		var unary *Parameter
 		if item.Unary && len(parameters) == 1 {
@@ -206,6 +281,9 @@ func (c *Converter) convertAliases(items []*ast.AliasDefinition) []Alias {
 	}
 	s := make([]Alias, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		s[i] = Alias{
 			Description: stringValuePtr(item.Description),
 			Name:        item.Name.Value,
@@ -222,6 +300,9 @@ func (c *Converter) convertUnions(items []*ast.UnionDefinition) []Union {
 	}
 	s := make([]Union, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		s[i] = Union{
 			Description: stringValuePtr(item.Description),
 			Name:        item.Name.Value,
@@ -291,6 +372,9 @@ func (c *Converter) convertDirectives(items []*ast.DirectiveDefinition) []Direct
 	}
 	s := make([]Directive, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		s[i] = Directive{
 			Description: stringValuePtr(item.Description),
 			Name:        item.Name.Value,
@@ -350,6 +434,9 @@ func (c *Converter) convertImports(items []*ast.ImportDefinition) []Import {
 	}
 	s := make([]Import, len(items))
 	for i, item := range items {
+		if c.done() {
+			break
+		}
 		s[i] = Import{
 			Description: stringValuePtr(item.Description),
 			All:         item.All,
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	msgpack "github.com/wapc/tinygo-msgpack"
+
+	"github.com/apexlang/apex-go/internal/testspecs"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// pipeline runs src through the parse, validate and convert pipeline.
+// It returns nil if any stage reports errors.
+func pipeline(src string) *Namespace {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  src,
+		Options: parser.ParseOptions{MaxDepth: 64},
+	})
+	if err != nil {
+		return nil
+	}
+	rules.Validate(doc, rules.Rules...)
+	ns, errs := Convert(doc)
+	if len(errs) > 0 {
+		return nil
+	}
+	return ns
+}
+
+// FuzzConvert checks that the pipeline does not panic and that JSON
+// and msgpack encoding preserve every namespace it produces.
+func FuzzConvert(f *testing.F) {
+	for _, b := range testspecs.Load(f) {
+		f.Add(string(b))
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		ns := pipeline(src)
+		if ns == nil {
+			return
+		}
+		checkJSONRoundTrip(t, ns)
+		checkMsgPackRoundTrip(t, ns)
+	})
+}
+
+func checkJSONRoundTrip(t *testing.T, ns *Namespace) {
+	t.Helper()
+	data, err := json.Marshal(ns)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded Namespace
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if !equal(ns, &decoded) {
+		t.Fatalf("JSON round trip changed the namespace:\n%s", data)
+	}
+}
+
+func checkMsgPackRoundTrip(t *testing.T, ns *Namespace) {
+	t.Helper()
+	data, err := msgpack.ToBytes(ns)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	var decoded Namespace
+	if err := Decode(data, &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !equal(ns, &decoded) {
+		t.Fatalf("msgpack round trip changed the namespace %q", ns.Name)
+	}
+}
+
+// equal reports whether a and b are deeply equal, treating nil and
+// empty slices alike since the encodings do not tell them apart.
+func equal(a, b interface{}) bool {
+	return equalValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func equalValues(a, b reflect.Value) bool {
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return equalValues(a.Elem(), b.Elem())
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float32, reflect.Float64:
+		// NaN has no encoding that compares equal to itself.
+		return a.Float() == b.Float() || a.Float() != a.Float() && b.Float() != b.Float()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
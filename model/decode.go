@@ -0,0 +1,282 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	msgpack "github.com/wapc/tinygo-msgpack"
+)
+
+// Decode decodes the msgpack payload data into c with the checks that
+// the generated decoders leave out:
+//
+//   - lists and maps cannot claim more items than data has bytes, so a
+//     forged size cannot make a decoder allocate more than the payload
+//     warrants;
+//   - a nil where the Scalar of a TypeRef is expected is an error
+//     rather than a nil dereference;
+//   - every decoded TypeRef and Value has exactly one member, and every
+//     enum value has a name, so that encoding c again preserves it.
+//
+// Payloads from outside the process, such as the arguments of waPC
+// calls, should be decoded with Decode rather than c.Decode.
+func Decode(data []byte, c msgpack.Codec) error {
+	decoder := msgpack.NewDecoder(data)
+	r := reader{Reader: &decoder, budget: uint64(len(data))}
+	if err := c.Decode(&r); err != nil {
+		return err
+	}
+	return check(c)
+}
+
+// reader bounds the sizes read by the generated decoders. Every item of
+// a list and every key and value of a map is an object of at least one
+// byte, so the sizes read from a payload cannot add up to more objects
+// than it has bytes.
+type reader struct {
+	msgpack.Reader
+	budget uint64
+}
+
+var errSizeExceedsPayload = errors.New("msgpack: size exceeds the payload")
+
+func (r *reader) ReadArraySize() (uint32, error) {
+	size, err := r.Reader.ReadArraySize()
+	if err != nil {
+		return 0, err
+	}
+	if err := r.claim(uint64(size)); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (r *reader) ReadMapSize() (uint32, error) {
+	size, err := r.Reader.ReadMapSize()
+	if err != nil {
+		return 0, err
+	}
+	if err := r.claim(2 * uint64(size)); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (r *reader) claim(objects uint64) error {
+	if objects > r.budget {
+		return errSizeExceedsPayload
+	}
+	r.budget -= objects
+	return nil
+}
+
+// ReadNillableInt32 never returns a nil pointer, since the generated
+// decoder passes it to convert.NillableNumeric, which dereferences it.
+// The only nillable int32 of the model is the Scalar of a TypeRef,
+// which is never encoded as nil.
+func (r *reader) ReadNillableInt32() (*int32, error) {
+	value, err := r.Reader.ReadNillableInt32()
+	if value == nil {
+		if err == nil {
+			err = errors.New("msgpack: unexpected nil")
+		}
+		return new(int32), err
+	}
+	return value, err
+}
+
+// check returns an error for the first union without exactly one
+// member or enum value without a name in v, which has no encoding that
+// reads back the same.
+func check(v interface{}) error {
+	var c checker
+	c.top(v)
+	return c.err
+}
+
+type checker struct {
+	err error
+}
+
+func (c *checker) failf(format string, args ...interface{}) {
+	if c.err == nil {
+		c.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (c *checker) top(v interface{}) {
+	switch v := v.(type) {
+	case *Namespace:
+		c.namespace(v)
+	case *ParserResult:
+		if v.Namespace != nil {
+			c.namespace(v.Namespace)
+		}
+	case *DiffResult:
+		for _, change := range v.Changes {
+			if _, ok := toStringChangeKind[change.Kind]; !ok {
+				c.failf("unknown ChangeKind %d", change.Kind)
+			}
+		}
+	case *ParserDiffArgs:
+		c.namespace(&v.From)
+		c.namespace(&v.To)
+	}
+}
+
+func (c *checker) namespace(ns *Namespace) {
+	c.annotations(ns.Annotations)
+	for _, item := range ns.Imports {
+		c.annotations(item.Annotations)
+	}
+	for _, item := range ns.Directives {
+		c.parameters(item.Parameters)
+		c.locations(item.Locations)
+		for _, require := range item.Require {
+			c.locations(require.Locations)
+		}
+	}
+	for _, item := range ns.Aliases {
+		c.typeRef(item.Type)
+		c.annotations(item.Annotations)
+	}
+	c.operations(ns.Functions)
+	for _, item := range ns.Interfaces {
+		c.operations(item.Operations)
+		c.annotations(item.Annotations)
+	}
+	for _, item := range ns.Types {
+		for _, field := range item.Fields {
+			c.typeRef(field.Type)
+			c.valuePtr(field.DefaultValue)
+			c.annotations(field.Annotations)
+		}
+		c.annotations(item.Annotations)
+	}
+	for _, item := range ns.Enums {
+		for _, value := range item.Values {
+			c.annotations(value.Annotations)
+		}
+		c.annotations(item.Annotations)
+	}
+	for _, item := range ns.Unions {
+		for _, t := range item.Types {
+			c.typeRef(t)
+		}
+		c.annotations(item.Annotations)
+	}
+}
+
+func (c *checker) operations(items []Operation) {
+	for _, item := range items {
+		c.parameters(item.Parameters)
+		if item.Unary != nil {
+			c.parameters([]Parameter{*item.Unary})
+		}
+		if item.Returns != nil {
+			c.typeRef(*item.Returns)
+		}
+		c.annotations(item.Annotations)
+	}
+}
+
+func (c *checker) parameters(items []Parameter) {
+	for _, item := range items {
+		c.typeRef(item.Type)
+		c.valuePtr(item.DefaultValue)
+		c.annotations(item.Annotations)
+	}
+}
+
+func (c *checker) locations(items []DirectiveLocation) {
+	for _, item := range items {
+		if _, ok := toStringDirectiveLocation[item]; !ok {
+			c.failf("unknown DirectiveLocation %d", item)
+		}
+	}
+}
+
+func (c *checker) annotations(items []Annotation) {
+	for _, item := range items {
+		for _, argument := range item.Arguments {
+			c.value(argument.Value)
+		}
+	}
+}
+
+func (c *checker) typeRef(t TypeRef) {
+	if n := members(t.Scalar != nil, t.Named != nil, t.List != nil,
+		t.Map != nil, t.Stream != nil, t.Optional != nil); n != 1 {
+		c.failf("TypeRef has %d members set", n)
+		return
+	}
+	switch {
+	case t.Scalar != nil:
+		if _, ok := toStringScalar[*t.Scalar]; !ok {
+			c.failf("unknown Scalar %d", *t.Scalar)
+		}
+	case t.Named != nil:
+		if _, ok := toStringKind[t.Named.Kind]; !ok {
+			c.failf("unknown Kind %d", t.Named.Kind)
+		}
+	case t.List != nil:
+		c.typeRef(t.List.Type)
+	case t.Map != nil:
+		c.typeRef(t.Map.KeyType)
+		c.typeRef(t.Map.ValueType)
+	case t.Stream != nil:
+		c.typeRef(t.Stream.Type)
+	case t.Optional != nil:
+		c.typeRef(t.Optional.Type)
+	}
+}
+
+func (c *checker) valuePtr(v *Value) {
+	if v != nil {
+		c.value(*v)
+	}
+}
+
+func (c *checker) value(v Value) {
+	if n := members(v.Bool != nil, v.String != nil, v.I64 != nil, v.F64 != nil,
+		v.Reference != nil, v.ListValue != nil, v.ObjectValue != nil); n != 1 {
+		c.failf("Value has %d members set", n)
+		return
+	}
+	switch {
+	case v.ListValue != nil:
+		for _, item := range v.ListValue.Values {
+			c.value(item)
+		}
+	case v.ObjectValue != nil:
+		for _, field := range v.ObjectValue.Fields {
+			c.value(field.Value)
+		}
+	}
+}
+
+func members(set ...bool) int {
+	n := 0
+	for _, member := range set {
+		if member {
+			n++
+		}
+	}
+	return n
+}
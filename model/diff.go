@@ -0,0 +1,367 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Diff returns the changes from one namespace to another. Elements are
+// matched by name, so a renamed element is reported as removed and
+// added.
+func Diff(from, to *Namespace) []Change {
+	if from == nil {
+		from = &Namespace{}
+	}
+	if to == nil {
+		to = &Namespace{}
+	}
+	d := differ{changes: []Change{}}
+	d.value("name", from.Name, to.Name)
+	d.description("description", from.Description, to.Description)
+	d.annotations("annotations", from.Annotations, to.Annotations)
+	diffNamed(&d, "imports", from.Imports, to.Imports,
+		func(i Import) string { return i.From }, importString, d.imports)
+	diffNamed(&d, "directives", from.Directives, to.Directives,
+		func(i Directive) string { return i.Name }, directiveString, d.directives)
+	diffNamed(&d, "aliases", from.Aliases, to.Aliases,
+		func(i Alias) string { return i.Name }, func(i Alias) string { return typeString(i.Type) }, d.aliases)
+	diffNamed(&d, "functions", from.Functions, to.Functions,
+		func(i Operation) string { return i.Name }, operationString, d.operations)
+	diffNamed(&d, "interfaces", from.Interfaces, to.Interfaces,
+		func(i Interface) string { return i.Name }, func(i Interface) string { return i.Name }, d.interfaces)
+	diffNamed(&d, "types", from.Types, to.Types,
+		func(i Type) string { return i.Name }, func(i Type) string { return i.Name }, d.types)
+	diffNamed(&d, "enums", from.Enums, to.Enums,
+		func(i Enum) string { return i.Name }, func(i Enum) string { return i.Name }, d.enums)
+	diffNamed(&d, "unions", from.Unions, to.Unions,
+		func(i Union) string { return i.Name }, func(i Union) string { return typesString(i.Types) }, d.unions)
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+}
+
+// diffNamed matches from and to by name, reports the removed and added
+// items and calls diff for the items in both.
+func diffNamed[T any](d *differ, path string, from, to []T, name, describe func(T) string, diff func(path string, from, to T)) {
+	existing := make(map[string]T, len(to))
+	for _, item := range to {
+		existing[name(item)] = item
+	}
+	previous := make(map[string]struct{}, len(from))
+	for _, item := range from {
+		n := name(item)
+		previous[n] = struct{}{}
+		if other, ok := existing[n]; ok {
+			diff(path+"."+n, item, other)
+		} else {
+			d.add(ChangeKindRemoved, path+"."+n, describe(item), "")
+		}
+	}
+	for _, item := range to {
+		n := name(item)
+		if _, ok := previous[n]; !ok {
+			d.add(ChangeKindAdded, path+"."+n, "", describe(item))
+		}
+	}
+}
+
+func (d *differ) add(kind ChangeKind, path, from, to string) {
+	change := Change{Kind: kind, Path: path}
+	if kind != ChangeKindAdded {
+		change.From = &from
+	}
+	if kind != ChangeKindRemoved {
+		change.To = &to
+	}
+	d.changes = append(d.changes, change)
+}
+
+func (d *differ) value(path, from, to string) {
+	if from != to {
+		d.add(ChangeKindChanged, path, from, to)
+	}
+}
+
+func (d *differ) description(path string, from, to *string) {
+	switch {
+	case from == nil && to != nil:
+		d.add(ChangeKindAdded, path, "", *to)
+	case from != nil && to == nil:
+		d.add(ChangeKindRemoved, path, *from, "")
+	case from != nil && to != nil:
+		d.value(path, *from, *to)
+	}
+}
+
+func (d *differ) defaultValue(path string, from, to *Value) {
+	switch {
+	case from == nil && to != nil:
+		d.add(ChangeKindAdded, path, "", valueString(*to))
+	case from != nil && to == nil:
+		d.add(ChangeKindRemoved, path, valueString(*from), "")
+	case from != nil && to != nil:
+		d.value(path, valueString(*from), valueString(*to))
+	}
+}
+
+func (d *differ) annotations(path string, from, to []Annotation) {
+	diffNamed(d, path, from, to,
+		func(a Annotation) string { return a.Name }, annotationString,
+		func(path string, from, to Annotation) {
+			d.value(path, annotationString(from), annotationString(to))
+		})
+}
+
+func (d *differ) imports(path string, from, to Import) {
+	d.description(path+".description", from.Description, to.Description)
+	d.value(path, importString(from), importString(to))
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) directives(path string, from, to Directive) {
+	d.description(path+".description", from.Description, to.Description)
+	d.parameters(path+".parameters", from.Parameters, to.Parameters)
+	d.value(path+".locations", locationsString(from.Locations), locationsString(to.Locations))
+	diffNamed(d, path+".require", from.Require, to.Require,
+		func(r DirectiveRequire) string { return r.Directive },
+		func(r DirectiveRequire) string { return locationsString(r.Locations) },
+		func(path string, from, to DirectiveRequire) {
+			d.value(path, locationsString(from.Locations), locationsString(to.Locations))
+		})
+}
+
+func (d *differ) aliases(path string, from, to Alias) {
+	d.description(path+".description", from.Description, to.Description)
+	d.value(path+".type", typeString(from.Type), typeString(to.Type))
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) operations(path string, from, to Operation) {
+	d.description(path+".description", from.Description, to.Description)
+	d.parameters(path+".parameters", from.Parameters, to.Parameters)
+	switch {
+	case from.Unary == nil && to.Unary != nil:
+		d.add(ChangeKindAdded, path+".unary", "", parameterString(*to.Unary))
+	case from.Unary != nil && to.Unary == nil:
+		d.add(ChangeKindRemoved, path+".unary", parameterString(*from.Unary), "")
+	case from.Unary != nil && to.Unary != nil:
+		d.parameter(path+".unary", *from.Unary, *to.Unary)
+	}
+	d.value(path+".returns", returnsString(from.Returns), returnsString(to.Returns))
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) parameters(path string, from, to []Parameter) {
+	diffNamed(d, path, from, to,
+		func(p Parameter) string { return p.Name }, parameterString, d.parameter)
+}
+
+func (d *differ) parameter(path string, from, to Parameter) {
+	d.description(path+".description", from.Description, to.Description)
+	d.value(path+".type", typeString(from.Type), typeString(to.Type))
+	d.defaultValue(path+".defaultValue", from.DefaultValue, to.DefaultValue)
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) interfaces(path string, from, to Interface) {
+	d.description(path+".description", from.Description, to.Description)
+	diffNamed(d, path+".operations", from.Operations, to.Operations,
+		func(o Operation) string { return o.Name }, operationString, d.operations)
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) types(path string, from, to Type) {
+	d.description(path+".description", from.Description, to.Description)
+	diffNamed(d, path+".fields", from.Fields, to.Fields,
+		func(f Field) string { return f.Name }, fieldString, d.fields)
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) fields(path string, from, to Field) {
+	d.description(path+".description", from.Description, to.Description)
+	d.value(path+".type", typeString(from.Type), typeString(to.Type))
+	d.defaultValue(path+".defaultValue", from.DefaultValue, to.DefaultValue)
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) enums(path string, from, to Enum) {
+	d.description(path+".description", from.Description, to.Description)
+	diffNamed(d, path+".values", from.Values, to.Values,
+		func(v EnumValue) string { return v.Name }, enumValueString, d.enumValues)
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) enumValues(path string, from, to EnumValue) {
+	d.description(path+".description", from.Description, to.Description)
+	d.value(path, enumValueString(from), enumValueString(to))
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func (d *differ) unions(path string, from, to Union) {
+	d.description(path+".description", from.Description, to.Description)
+	d.value(path+".types", typesString(from.Types), typesString(to.Types))
+	d.annotations(path+".annotations", from.Annotations, to.Annotations)
+}
+
+func importString(i Import) string {
+	if i.All {
+		return "* from " + strconv.Quote(i.From)
+	}
+	names := make([]string, len(i.Names))
+	for j, name := range i.Names {
+		names[j] = name.Name
+		if name.As != nil {
+			names[j] += " as " + *name.As
+		}
+	}
+	return "{ " + strings.Join(names, ", ") + " } from " + strconv.Quote(i.From)
+}
+
+func enumValueString(v EnumValue) string {
+	s := v.Name + " = " + strconv.FormatUint(v.Index, 10)
+	if v.Display != nil {
+		s += " as " + strconv.Quote(*v.Display)
+	}
+	return s
+}
+
+func directiveString(d Directive) string {
+	return "@" + d.Name + parametersString(d.Parameters) + " on " + locationsString(d.Locations)
+}
+
+func locationsString(locations []DirectiveLocation) string {
+	names := make([]string, len(locations))
+	for i, l := range locations {
+		names[i] = l.String()
+	}
+	return strings.Join(names, " | ")
+}
+
+func operationString(o Operation) string {
+	s := o.Name
+	if o.Unary != nil {
+		s += "[" + parameterString(*o.Unary) + "]"
+	} else {
+		s += parametersString(o.Parameters)
+	}
+	if o.Returns != nil {
+		s += ": " + typeString(*o.Returns)
+	}
+	return s
+}
+
+func returnsString(t *TypeRef) string {
+	if t == nil {
+		return "void"
+	}
+	return typeString(*t)
+}
+
+func parametersString(params []Parameter) string {
+	items := make([]string, len(params))
+	for i, p := range params {
+		items[i] = parameterString(p)
+	}
+	return "(" + strings.Join(items, ", ") + ")"
+}
+
+func parameterString(p Parameter) string {
+	return valuedString(p.Name, p.Type, p.DefaultValue)
+}
+
+func fieldString(f Field) string {
+	return valuedString(f.Name, f.Type, f.DefaultValue)
+}
+
+func valuedString(name string, t TypeRef, defaultValue *Value) string {
+	s := name + ": " + typeString(t)
+	if defaultValue != nil {
+		s += " = " + valueString(*defaultValue)
+	}
+	return s
+}
+
+func typesString(types []TypeRef) string {
+	items := make([]string, len(types))
+	for i, t := range types {
+		items[i] = typeString(t)
+	}
+	return strings.Join(items, " | ")
+}
+
+// typeString returns t as it is written in a spec.
+func typeString(t TypeRef) string {
+	switch {
+	case t.Scalar != nil:
+		return strings.ToLower(t.Scalar.String())
+	case t.Named != nil:
+		return t.Named.Name
+	case t.List != nil:
+		return "[" + typeString(t.List.Type) + "]"
+	case t.Map != nil:
+		return "{" + typeString(t.Map.KeyType) + ": " + typeString(t.Map.ValueType) + "}"
+	case t.Stream != nil:
+		return "stream " + typeString(t.Stream.Type)
+	case t.Optional != nil:
+		return typeString(t.Optional.Type) + "?"
+	}
+	return ""
+}
+
+func annotationString(a Annotation) string {
+	if len(a.Arguments) == 0 {
+		return "@" + a.Name
+	}
+	args := make([]string, len(a.Arguments))
+	for i, arg := range a.Arguments {
+		args[i] = arg.Name + ": " + valueString(arg.Value)
+	}
+	return "@" + a.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// valueString returns v as it is written in a spec.
+func valueString(v Value) string {
+	switch {
+	case v.Bool != nil:
+		return strconv.FormatBool(*v.Bool)
+	case v.String != nil:
+		return strconv.Quote(*v.String)
+	case v.I64 != nil:
+		return strconv.FormatInt(*v.I64, 10)
+	case v.F64 != nil:
+		return strconv.FormatFloat(*v.F64, 'g', -1, 64)
+	case v.Reference != nil:
+		return v.Reference.Name
+	case v.ListValue != nil:
+		items := make([]string, len(v.ListValue.Values))
+		for i, item := range v.ListValue.Values {
+			items[i] = valueString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case v.ObjectValue != nil:
+		fields := make([]string, len(v.ObjectValue.Fields))
+		for i, field := range v.ObjectValue.Fields {
+			fields[i] = field.Name + ": " + valueString(field.Value)
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		changes  []string
+	}{
+		{
+			name: "unchanged",
+			from: `namespace "a"
+type T { a: string }`,
+			to: `namespace "a"
+type T { a: string }`,
+			changes: nil,
+		},
+		{
+			name: "namespace renamed",
+			from: `namespace "a"`,
+			to:   `namespace "b"`,
+			changes: []string{
+				`CHANGED name: "a" -> "b"`,
+			},
+		},
+		{
+			name: "field added",
+			from: `namespace "a"
+type T { a: string }`,
+			to: `namespace "a"
+type T { a: string b: i32? }`,
+			changes: []string{
+				`ADDED types.T.fields.b: <nil> -> "b: i32?"`,
+			},
+		},
+		{
+			name: "field removed",
+			from: `namespace "a"
+type T { a: string b: i32 }`,
+			to: `namespace "a"
+type T { a: string }`,
+			changes: []string{
+				`REMOVED types.T.fields.b: "b: i32" -> <nil>`,
+			},
+		},
+		{
+			name: "field type and default changed",
+			from: `namespace "a"
+type T { a: string }`,
+			to: `namespace "a"
+type T { a: [string] = ["x"] }`,
+			changes: []string{
+				`CHANGED types.T.fields.a.type: "string" -> "[string]"`,
+				`ADDED types.T.fields.a.defaultValue: <nil> -> "[\"x\"]"`,
+			},
+		},
+		{
+			name: "description added, changed and removed",
+			from: `namespace "a"
+"T" type T { "a" a: string "b" b: string }`,
+			to: `namespace "a"
+"U" type T { a: string "b" b: string }
+"E" enum E { A = 0 }`,
+			changes: []string{
+				`CHANGED types.T.description: "T" -> "U"`,
+				`REMOVED types.T.fields.a.description: "a" -> <nil>`,
+				`ADDED enums.E: <nil> -> "E"`,
+			},
+		},
+		{
+			name: "renamed type",
+			from: `namespace "a"
+type T { a: string }`,
+			to: `namespace "a"
+type U { a: string }`,
+			changes: []string{
+				`REMOVED types.T: "T" -> <nil>`,
+				`ADDED types.U: <nil> -> "U"`,
+			},
+		},
+		{
+			name: "operations",
+			from: `namespace "a"
+interface I { get(id: string): string drop(): void }`,
+			to: `namespace "a"
+interface I { get[id: string]: string? put(): void }`,
+			changes: []string{
+				`REMOVED interfaces.I.operations.get.parameters.id: "id: string" -> <nil>`,
+				`ADDED interfaces.I.operations.get.unary: <nil> -> "id: string"`,
+				`CHANGED interfaces.I.operations.get.returns: "string" -> "string?"`,
+				`REMOVED interfaces.I.operations.drop: "drop()" -> <nil>`,
+				`ADDED interfaces.I.operations.put: <nil> -> "put()"`,
+			},
+		},
+		{
+			name: "enum values, unions and annotations",
+			from: `namespace "a"
+enum E { A = 0 B = 1 }
+type T @x(v: 1) { a: string }
+union U = T | string`,
+			to: `namespace "a"
+enum E { A = 0 as "a" C = 2 }
+type T @x(v: 2) @y { a: string }
+union U = T | i32`,
+			changes: []string{
+				`CHANGED types.T.annotations.x: "@x(v: 1)" -> "@x(v: 2)"`,
+				`ADDED types.T.annotations.y: <nil> -> "@y"`,
+				`CHANGED enums.E.values.A: "A = 0" -> "A = 0 as \"a\""`,
+				`REMOVED enums.E.values.B: "B = 1" -> <nil>`,
+				`ADDED enums.E.values.C: <nil> -> "C = 2"`,
+				`CHANGED unions.U.types: "T | string" -> "T | i32"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := pipeline(tt.from), pipeline(tt.to)
+			if from == nil || to == nil {
+				t.Fatal("spec does not convert")
+			}
+			var changes []string
+			for _, c := range Diff(from, to) {
+				changes = append(changes, changeString(c))
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("got changes\n%q\nwant\n%q", changes, tt.changes)
+			}
+		})
+	}
+}
+
+func TestDiffNil(t *testing.T) {
+	ns := pipeline(`namespace "a"
+type T { a: string }`)
+	tests := []struct {
+		from, to *Namespace
+		changes  []string
+	}{
+		{nil, ns, []string{`CHANGED name: "" -> "a"`, `ADDED types.T: <nil> -> "T"`}},
+		{ns, nil, []string{`CHANGED name: "a" -> ""`, `REMOVED types.T: "T" -> <nil>`}},
+		{nil, nil, nil},
+	}
+	for _, tt := range tests {
+		var changes []string
+		for _, c := range Diff(tt.from, tt.to) {
+			changes = append(changes, changeString(c))
+		}
+		if !reflect.DeepEqual(changes, tt.changes) {
+			t.Errorf("got changes %q, want %q", changes, tt.changes)
+		}
+	}
+}
+
+// changeString returns c in the compact form used by the tests.
+func changeString(c Change) string {
+	return c.Kind.String() + " " + c.Path + ": " + quoted(c.From) + " -> " + quoted(c.To)
+}
+
+func quoted(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return strconv.Quote(*s)
+}
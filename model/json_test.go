@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apexlang/apex-go/internal/testspecs"
+)
+
+// values returns a value of each type unmarshaled from JSON documents.
+func values() []interface{} {
+	return []interface{}{
+		&Namespace{},
+		&ParserResult{},
+		&ValidateResult{},
+		&ASTResult{},
+		&FormatResult{},
+		&DiffResult{},
+	}
+}
+
+// FuzzUnmarshalJSON checks that unmarshaling arbitrary documents does
+// not panic and that marshaling preserves whatever was unmarshaled.
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, spec := range testspecs.Load(f) {
+		ns := pipeline(string(spec))
+		if ns == nil {
+			continue
+		}
+		data, err := json.Marshal(ns)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(uint8(0), data)
+		data, err = json.Marshal(&ParserResult{Namespace: ns, Errors: []Error{{Message: "message"}}})
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(uint8(1), data)
+	}
+	f.Fuzz(func(t *testing.T, kind uint8, data []byte) {
+		all := values()
+		decoded := all[int(kind)%len(all)]
+		if err := json.Unmarshal(data, decoded); err != nil {
+			return
+		}
+		if check(decoded) != nil {
+			// Unnamed enum values, such as a missing kind, marshal as
+			// "unknown", which does not unmarshal.
+			return
+		}
+		encoded, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		again := values()[int(kind)%len(all)]
+		if err := json.Unmarshal(encoded, again); err != nil {
+			t.Fatalf("unmarshal of %s: %v", encoded, err)
+		}
+		if !equal(decoded, again) {
+			t.Fatalf("JSON round trip changed the %T:\n%s", decoded, encoded)
+		}
+	})
+}
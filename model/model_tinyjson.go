@@ -1704,6 +1704,29 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel12(in *jlexer.Lexer, out
 				}
 				in.Delim(']')
 			}
+		case "enums":
+			if in.IsNull() {
+				in.Skip()
+				out.Enums = nil
+			} else {
+				in.Delim('[')
+				if out.Enums == nil {
+					if !in.IsDelim(']') {
+						out.Enums = make([]Enum, 0, 0)
+					} else {
+						out.Enums = []Enum{}
+					}
+				} else {
+					out.Enums = (out.Enums)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v35 Enum
+					(v35).UnmarshalTinyJSON(in)
+					out.Enums = append(out.Enums, v35)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "unions":
 			if in.IsNull() {
 				in.Skip()
@@ -1720,9 +1743,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel12(in *jlexer.Lexer, out
 					out.Unions = (out.Unions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v35 Union
-					(v35).UnmarshalTinyJSON(in)
-					out.Unions = append(out.Unions, v35)
+					var v36 Union
+					(v36).UnmarshalTinyJSON(in)
+					out.Unions = append(out.Unions, v36)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1756,11 +1779,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v36, v37 := range in.Annotations {
-				if v36 > 0 {
+			for v37, v38 := range in.Annotations {
+				if v37 > 0 {
 					out.RawByte(',')
 				}
-				(v37).MarshalTinyJSON(out)
+				(v38).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1770,11 +1793,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v38, v39 := range in.Imports {
-				if v38 > 0 {
+			for v39, v40 := range in.Imports {
+				if v39 > 0 {
 					out.RawByte(',')
 				}
-				(v39).MarshalTinyJSON(out)
+				(v40).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1784,11 +1807,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v40, v41 := range in.Directives {
-				if v40 > 0 {
+			for v41, v42 := range in.Directives {
+				if v41 > 0 {
 					out.RawByte(',')
 				}
-				(v41).MarshalTinyJSON(out)
+				(v42).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1798,11 +1821,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v42, v43 := range in.Aliases {
-				if v42 > 0 {
+			for v43, v44 := range in.Aliases {
+				if v43 > 0 {
 					out.RawByte(',')
 				}
-				(v43).MarshalTinyJSON(out)
+				(v44).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1812,11 +1835,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v44, v45 := range in.Functions {
-				if v44 > 0 {
+			for v45, v46 := range in.Functions {
+				if v45 > 0 {
 					out.RawByte(',')
 				}
-				(v45).MarshalTinyJSON(out)
+				(v46).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1826,11 +1849,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v46, v47 := range in.Interfaces {
-				if v46 > 0 {
+			for v47, v48 := range in.Interfaces {
+				if v47 > 0 {
 					out.RawByte(',')
 				}
-				(v47).MarshalTinyJSON(out)
+				(v48).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1840,11 +1863,25 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v48, v49 := range in.Types {
-				if v48 > 0 {
+			for v49, v50 := range in.Types {
+				if v49 > 0 {
+					out.RawByte(',')
+				}
+				(v50).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Enums) != 0 {
+		const prefix string = ",\"enums\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v51, v52 := range in.Enums {
+				if v51 > 0 {
 					out.RawByte(',')
 				}
-				(v49).MarshalTinyJSON(out)
+				(v52).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1854,11 +1891,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel12(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v50, v51 := range in.Unions {
-				if v50 > 0 {
+			for v53, v54 := range in.Unions {
+				if v53 > 0 {
 					out.RawByte(',')
 				}
-				(v51).MarshalTinyJSON(out)
+				(v54).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2145,9 +2182,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel16(in *jlexer.Lexer, out
 					out.Values = (out.Values)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v52 Value
-					(v52).UnmarshalTinyJSON(in)
-					out.Values = append(out.Values, v52)
+					var v55 Value
+					(v55).UnmarshalTinyJSON(in)
+					out.Values = append(out.Values, v55)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2173,11 +2210,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel16(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v53, v54 := range in.Values {
-				if v53 > 0 {
+			for v56, v57 := range in.Values {
+				if v56 > 0 {
 					out.RawByte(',')
 				}
-				(v54).MarshalTinyJSON(out)
+				(v57).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2321,9 +2358,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel18(in *jlexer.Lexer, out
 					out.Operations = (out.Operations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v55 Operation
-					(v55).UnmarshalTinyJSON(in)
-					out.Operations = append(out.Operations, v55)
+					var v58 Operation
+					(v58).UnmarshalTinyJSON(in)
+					out.Operations = append(out.Operations, v58)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2344,9 +2381,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel18(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v56 Annotation
-					(v56).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v56)
+					var v59 Annotation
+					(v59).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v59)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2382,11 +2419,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel18(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v57, v58 := range in.Operations {
-				if v57 > 0 {
+			for v60, v61 := range in.Operations {
+				if v60 > 0 {
 					out.RawByte(',')
 				}
-				(v58).MarshalTinyJSON(out)
+				(v61).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2396,11 +2433,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel18(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v59, v60 := range in.Annotations {
-				if v59 > 0 {
+			for v62, v63 := range in.Annotations {
+				if v62 > 0 {
 					out.RawByte(',')
 				}
-				(v60).MarshalTinyJSON(out)
+				(v63).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2559,9 +2596,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel20(in *jlexer.Lexer, out
 					out.Names = (out.Names)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v61 ImportRef
-					(v61).UnmarshalTinyJSON(in)
-					out.Names = append(out.Names, v61)
+					var v64 ImportRef
+					(v64).UnmarshalTinyJSON(in)
+					out.Names = append(out.Names, v64)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2584,9 +2621,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel20(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v62 Annotation
-					(v62).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v62)
+					var v65 Annotation
+					(v65).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v65)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2626,11 +2663,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel20(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v63, v64 := range in.Names {
-				if v63 > 0 {
+			for v66, v67 := range in.Names {
+				if v66 > 0 {
 					out.RawByte(',')
 				}
-				(v64).MarshalTinyJSON(out)
+				(v67).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2645,11 +2682,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel20(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v65, v66 := range in.Annotations {
-				if v65 > 0 {
+			for v68, v69 := range in.Annotations {
+				if v68 > 0 {
 					out.RawByte(',')
 				}
-				(v66).MarshalTinyJSON(out)
+				(v69).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2739,9 +2776,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel21(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v67 Annotation
-					(v67).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v67)
+					var v70 Annotation
+					(v70).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v70)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2785,11 +2822,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel21(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v68, v69 := range in.Annotations {
-				if v68 > 0 {
+			for v71, v72 := range in.Annotations {
+				if v71 > 0 {
 					out.RawByte(',')
 				}
-				(v69).MarshalTinyJSON(out)
+				(v72).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2857,9 +2894,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel22(in *jlexer.Lexer, out
 					out.Positions = (out.Positions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v70 uint32
-					v70 = uint32(in.Uint32())
-					out.Positions = append(out.Positions, v70)
+					var v73 uint32
+					v73 = uint32(in.Uint32())
+					out.Positions = append(out.Positions, v73)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2880,9 +2917,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel22(in *jlexer.Lexer, out
 					out.Locations = (out.Locations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v71 Location
-					(v71).UnmarshalTinyJSON(in)
-					out.Locations = append(out.Locations, v71)
+					var v74 Location
+					(v74).UnmarshalTinyJSON(in)
+					out.Locations = append(out.Locations, v74)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2913,11 +2950,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel22(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v72, v73 := range in.Positions {
-				if v72 > 0 {
+			for v75, v76 := range in.Positions {
+				if v75 > 0 {
 					out.RawByte(',')
 				}
-				out.Uint32(uint32(v73))
+				out.Uint32(uint32(v76))
 			}
 			out.RawByte(']')
 		}
@@ -2929,11 +2966,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel22(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v74, v75 := range in.Locations {
-				if v74 > 0 {
+			for v77, v78 := range in.Locations {
+				if v77 > 0 {
 					out.RawByte(',')
 				}
-				(v75).MarshalTinyJSON(out)
+				(v78).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3023,9 +3060,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel23(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v76 Annotation
-					(v76).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v76)
+					var v79 Annotation
+					(v79).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v79)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3069,11 +3106,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel23(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v77, v78 := range in.Annotations {
-				if v77 > 0 {
+			for v80, v81 := range in.Annotations {
+				if v80 > 0 {
 					out.RawByte(',')
 				}
-				(v78).MarshalTinyJSON(out)
+				(v81).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3151,9 +3188,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel24(in *jlexer.Lexer, out
 					out.Values = (out.Values)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v79 EnumValue
-					(v79).UnmarshalTinyJSON(in)
-					out.Values = append(out.Values, v79)
+					var v82 EnumValue
+					(v82).UnmarshalTinyJSON(in)
+					out.Values = append(out.Values, v82)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3174,9 +3211,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel24(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v80 Annotation
-					(v80).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v80)
+					var v83 Annotation
+					(v83).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v83)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3212,11 +3249,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel24(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v81, v82 := range in.Values {
-				if v81 > 0 {
+			for v84, v85 := range in.Values {
+				if v84 > 0 {
 					out.RawByte(',')
 				}
-				(v82).MarshalTinyJSON(out)
+				(v85).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3226,11 +3263,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel24(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v83, v84 := range in.Annotations {
-				if v83 > 0 {
+			for v86, v87 := range in.Annotations {
+				if v86 > 0 {
 					out.RawByte(',')
 				}
-				(v84).MarshalTinyJSON(out)
+				(v87).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3298,11 +3335,11 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel25(in *jlexer.Lexer, out
 					out.Locations = (out.Locations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v85 DirectiveLocation
+					var v88 DirectiveLocation
 					if data := in.Raw(); in.Ok() {
-						in.AddError((v85).UnmarshalJSON(data))
+						in.AddError((v88).UnmarshalJSON(data))
 					}
-					out.Locations = append(out.Locations, v85)
+					out.Locations = append(out.Locations, v88)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3333,11 +3370,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel25(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v86, v87 := range in.Locations {
-				if v86 > 0 {
+			for v89, v90 := range in.Locations {
+				if v89 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v87).MarshalJSON())
+				out.Raw((v90).MarshalJSON())
 			}
 			out.RawByte(']')
 		}
@@ -3415,9 +3452,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel26(in *jlexer.Lexer, out
 					out.Parameters = (out.Parameters)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v88 Parameter
-					(v88).UnmarshalTinyJSON(in)
-					out.Parameters = append(out.Parameters, v88)
+					var v91 Parameter
+					(v91).UnmarshalTinyJSON(in)
+					out.Parameters = append(out.Parameters, v91)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3438,11 +3475,11 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel26(in *jlexer.Lexer, out
 					out.Locations = (out.Locations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v89 DirectiveLocation
+					var v92 DirectiveLocation
 					if data := in.Raw(); in.Ok() {
-						in.AddError((v89).UnmarshalJSON(data))
+						in.AddError((v92).UnmarshalJSON(data))
 					}
-					out.Locations = append(out.Locations, v89)
+					out.Locations = append(out.Locations, v92)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3463,9 +3500,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel26(in *jlexer.Lexer, out
 					out.Require = (out.Require)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v90 DirectiveRequire
-					(v90).UnmarshalTinyJSON(in)
-					out.Require = append(out.Require, v90)
+					var v93 DirectiveRequire
+					(v93).UnmarshalTinyJSON(in)
+					out.Require = append(out.Require, v93)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3499,11 +3536,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel26(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v91, v92 := range in.Parameters {
-				if v91 > 0 {
+			for v94, v95 := range in.Parameters {
+				if v94 > 0 {
 					out.RawByte(',')
 				}
-				(v92).MarshalTinyJSON(out)
+				(v95).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3515,11 +3552,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel26(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v93, v94 := range in.Locations {
-				if v93 > 0 {
+			for v96, v97 := range in.Locations {
+				if v96 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v94).MarshalJSON())
+				out.Raw((v97).MarshalJSON())
 			}
 			out.RawByte(']')
 		}
@@ -3531,11 +3568,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel26(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v95, v96 := range in.Require {
-				if v95 > 0 {
+			for v98, v99 := range in.Require {
+				if v98 > 0 {
 					out.RawByte(',')
 				}
-				(v96).MarshalTinyJSON(out)
+				(v99).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3676,9 +3713,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(in *jlexer.Lexer, out
 					out.Arguments = (out.Arguments)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v97 Argument
-					(v97).UnmarshalTinyJSON(in)
-					out.Arguments = append(out.Arguments, v97)
+					var v100 Argument
+					(v100).UnmarshalTinyJSON(in)
+					out.Arguments = append(out.Arguments, v100)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3707,11 +3744,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v98, v99 := range in.Arguments {
-				if v98 > 0 {
+			for v101, v102 := range in.Arguments {
+				if v101 > 0 {
 					out.RawByte(',')
 				}
-				(v99).MarshalTinyJSON(out)
+				(v102).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3791,9 +3828,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v100 Annotation
-					(v100).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v100)
+					var v103 Annotation
+					(v103).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v103)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3832,11 +3869,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v101, v102 := range in.Annotations {
-				if v101 > 0 {
+			for v104, v105 := range in.Annotations {
+				if v104 > 0 {
 					out.RawByte(',')
 				}
-				(v102).MarshalTinyJSON(out)
+				(v105).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
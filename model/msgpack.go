@@ -396,6 +396,21 @@ func (o *Namespace) Decode(decoder msgpack.Reader) error {
 				}
 				o.Types = append(o.Types, nonNilItem)
 			}
+		case "enums":
+			listSize, err := decoder.ReadArraySize()
+			if err != nil {
+				return err
+			}
+			o.Enums = make([]Enum, 0, listSize)
+			for listSize > 0 {
+				listSize--
+				var nonNilItem Enum
+				err = nonNilItem.Decode(decoder)
+				if err != nil {
+					return err
+				}
+				o.Enums = append(o.Enums, nonNilItem)
+			}
 		case "unions":
 			listSize, err := decoder.ReadArraySize()
 			if err != nil {
@@ -427,7 +442,7 @@ func (o *Namespace) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(10)
+	encoder.WriteMapSize(11)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -467,6 +482,11 @@ func (o *Namespace) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Types {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("enums")
+	encoder.WriteArraySize(uint32(len(o.Enums)))
+	for _, v := range o.Enums {
+		v.Encode(encoder)
+	}
 	encoder.WriteString("unions")
 	encoder.WriteArraySize(uint32(len(o.Unions)))
 	for _, v := range o.Unions {
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	msgpack "github.com/wapc/tinygo-msgpack"
+
+	"github.com/apexlang/apex-go/internal/testspecs"
+)
+
+// codecs returns a value of each type decoded from waPC payloads.
+func codecs() []msgpack.Codec {
+	return []msgpack.Codec{
+		&Namespace{},
+		&ParserResult{},
+		&ValidateResult{},
+		&ASTResult{},
+		&FormatResult{},
+		&DiffResult{},
+		&ParserParseArgs{},
+		&ParserDiffArgs{},
+		&ResolverResolveArgs{},
+	}
+}
+
+// FuzzDecode checks that decoding arbitrary payloads with Decode does
+// not panic and that encoding preserves whatever was decoded.
+func FuzzDecode(f *testing.F) {
+	for _, spec := range testspecs.Load(f) {
+		ns := pipeline(string(spec))
+		if ns == nil {
+			continue
+		}
+		seeds := []msgpack.Codec{
+			ns,
+			&ParserResult{Namespace: ns, Errors: []Error{{Message: "message", Positions: []uint32{1}, Locations: []Location{{Line: 1, Column: 2}}}}},
+			&ParserDiffArgs{From: *ns, To: *ns},
+		}
+		for i, seed := range seeds {
+			data, err := msgpack.ToBytes(seed)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(uint8([]int{0, 1, 7}[i]), data)
+		}
+	}
+	f.Fuzz(func(t *testing.T, kind uint8, data []byte) {
+		all := codecs()
+		decoded := all[int(kind)%len(all)]
+		if err := Decode(data, decoded); err != nil {
+			return
+		}
+		encoded, err := msgpack.ToBytes(decoded)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		again := codecs()[int(kind)%len(all)]
+		if err := Decode(encoded, again); err != nil {
+			t.Fatalf("decode of encoded %T: %v", decoded, err)
+		}
+		if !equal(decoded, again) {
+			t.Fatalf("msgpack round trip changed the %T", decoded)
+		}
+	})
+}
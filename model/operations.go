@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+//go:generate tinyjson -all operations.go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ParserService is a Parser with the operations that model.axdl does
+// not describe. They are written by hand, so running apex generate
+// leaves them in place.
+type ParserService interface {
+	Parser
+	Validate(ctx context.Context, source string) (*ValidateResult, error)
+	AST(ctx context.Context, source string) (*ASTResult, error)
+	Format(ctx context.Context, source string) (*FormatResult, error)
+	Diff(ctx context.Context, from *Namespace, to *Namespace) (*DiffResult, error)
+}
+
+// ValidateResult holds the diagnostics of a spec.
+type ValidateResult struct {
+	Errors []Error `json:"errors,omitempty" yaml:"errors,omitempty" msgpack:"errors,omitempty"`
+}
+
+// ASTResult holds the document as JSON.
+type ASTResult struct {
+	Document *string `json:"document,omitempty" yaml:"document,omitempty" msgpack:"document,omitempty"`
+	Errors   []Error `json:"errors,omitempty" yaml:"errors,omitempty" msgpack:"errors,omitempty"`
+}
+
+// FormatResult holds the formatted spec.
+type FormatResult struct {
+	Source *string `json:"source,omitempty" yaml:"source,omitempty" msgpack:"source,omitempty"`
+	Errors []Error `json:"errors,omitempty" yaml:"errors,omitempty" msgpack:"errors,omitempty"`
+}
+
+// DiffResult holds the changes between two namespaces.
+type DiffResult struct {
+	Changes []Change `json:"changes" yaml:"changes" msgpack:"changes"`
+}
+
+// Change is a difference between two namespaces. The path names the changed
+// element, such as types.User.fields.id.
+type Change struct {
+	Kind ChangeKind `json:"kind" yaml:"kind" msgpack:"kind"`
+	Path string     `json:"path" yaml:"path" msgpack:"path"`
+	From *string    `json:"from,omitempty" yaml:"from,omitempty" msgpack:"from,omitempty"`
+	To   *string    `json:"to,omitempty" yaml:"to,omitempty" msgpack:"to,omitempty"`
+}
+
+// ChangeKind is the kind of a Change.
+type ChangeKind int32
+
+const (
+	ChangeKindAdded   ChangeKind = 1
+	ChangeKindRemoved ChangeKind = 2
+	ChangeKindChanged ChangeKind = 3
+)
+
+var toStringChangeKind = map[ChangeKind]string{
+	ChangeKindAdded:   "ADDED",
+	ChangeKindRemoved: "REMOVED",
+	ChangeKindChanged: "CHANGED",
+}
+
+var toIDChangeKind = map[string]ChangeKind{
+	"ADDED":   ChangeKindAdded,
+	"REMOVED": ChangeKindRemoved,
+	"CHANGED": ChangeKindChanged,
+}
+
+func (e ChangeKind) String() string {
+	str, ok := toStringChangeKind[e]
+	if !ok {
+		return "unknown"
+	}
+	return str
+}
+
+func (e *ChangeKind) FromString(str string) error {
+	var ok bool
+	*e, ok = toIDChangeKind[str]
+	if !ok {
+		return errors.New("unknown value \"" + str + "\" for ChangeKind")
+	}
+	return nil
+}
+
+// MarshalJSON marshals the enum as a quoted json string
+func (e ChangeKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON unmashals a quoted json string to the enum value
+func (e *ChangeKind) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+	return e.FromString(str)
+}
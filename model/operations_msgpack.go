@@ -0,0 +1,473 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"github.com/wapc/tinygo-msgpack"
+	"github.com/wapc/tinygo-msgpack/convert"
+)
+
+// The arguments of the operations of ParserService and the msgpack
+// codecs of their types, written in the shape of the generated ones in
+// msgpack.go.
+
+type ParserValidateArgs struct {
+	Source string `json:"source" yaml:"source" msgpack:"source"`
+}
+
+func (o *ParserValidateArgs) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "source":
+			o.Source, err = decoder.ReadString()
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *ParserValidateArgs) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(1)
+	encoder.WriteString("source")
+	encoder.WriteString(o.Source)
+
+	return nil
+}
+
+type ParserASTArgs struct {
+	Source string `json:"source" yaml:"source" msgpack:"source"`
+}
+
+func (o *ParserASTArgs) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "source":
+			o.Source, err = decoder.ReadString()
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *ParserASTArgs) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(1)
+	encoder.WriteString("source")
+	encoder.WriteString(o.Source)
+
+	return nil
+}
+
+type ParserFormatArgs struct {
+	Source string `json:"source" yaml:"source" msgpack:"source"`
+}
+
+func (o *ParserFormatArgs) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "source":
+			o.Source, err = decoder.ReadString()
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *ParserFormatArgs) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(1)
+	encoder.WriteString("source")
+	encoder.WriteString(o.Source)
+
+	return nil
+}
+
+type ParserDiffArgs struct {
+	From Namespace `json:"from" yaml:"from" msgpack:"from"`
+	To   Namespace `json:"to" yaml:"to" msgpack:"to"`
+}
+
+func (o *ParserDiffArgs) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "from":
+			err = o.From.Decode(decoder)
+		case "to":
+			err = o.To.Decode(decoder)
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *ParserDiffArgs) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("from")
+	o.From.Encode(encoder)
+	encoder.WriteString("to")
+	o.To.Encode(encoder)
+
+	return nil
+}
+
+func (o *ValidateResult) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "errors":
+			listSize, err := decoder.ReadArraySize()
+			if err != nil {
+				return err
+			}
+			o.Errors = make([]Error, 0, listSize)
+			for listSize > 0 {
+				listSize--
+				var nonNilItem Error
+				err = nonNilItem.Decode(decoder)
+				if err != nil {
+					return err
+				}
+				o.Errors = append(o.Errors, nonNilItem)
+			}
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *ValidateResult) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(1)
+	encoder.WriteString("errors")
+	encoder.WriteArraySize(uint32(len(o.Errors)))
+	for _, v := range o.Errors {
+		v.Encode(encoder)
+	}
+
+	return nil
+}
+
+func (o *ASTResult) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "document":
+			o.Document, err = decoder.ReadNillableString()
+		case "errors":
+			listSize, err := decoder.ReadArraySize()
+			if err != nil {
+				return err
+			}
+			o.Errors = make([]Error, 0, listSize)
+			for listSize > 0 {
+				listSize--
+				var nonNilItem Error
+				err = nonNilItem.Decode(decoder)
+				if err != nil {
+					return err
+				}
+				o.Errors = append(o.Errors, nonNilItem)
+			}
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *ASTResult) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("document")
+	encoder.WriteNillableString(o.Document)
+	encoder.WriteString("errors")
+	encoder.WriteArraySize(uint32(len(o.Errors)))
+	for _, v := range o.Errors {
+		v.Encode(encoder)
+	}
+
+	return nil
+}
+
+func (o *FormatResult) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "source":
+			o.Source, err = decoder.ReadNillableString()
+		case "errors":
+			listSize, err := decoder.ReadArraySize()
+			if err != nil {
+				return err
+			}
+			o.Errors = make([]Error, 0, listSize)
+			for listSize > 0 {
+				listSize--
+				var nonNilItem Error
+				err = nonNilItem.Decode(decoder)
+				if err != nil {
+					return err
+				}
+				o.Errors = append(o.Errors, nonNilItem)
+			}
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *FormatResult) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("source")
+	encoder.WriteNillableString(o.Source)
+	encoder.WriteString("errors")
+	encoder.WriteArraySize(uint32(len(o.Errors)))
+	for _, v := range o.Errors {
+		v.Encode(encoder)
+	}
+
+	return nil
+}
+
+func (o *DiffResult) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "changes":
+			listSize, err := decoder.ReadArraySize()
+			if err != nil {
+				return err
+			}
+			o.Changes = make([]Change, 0, listSize)
+			for listSize > 0 {
+				listSize--
+				var nonNilItem Change
+				err = nonNilItem.Decode(decoder)
+				if err != nil {
+					return err
+				}
+				o.Changes = append(o.Changes, nonNilItem)
+			}
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *DiffResult) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(1)
+	encoder.WriteString("changes")
+	encoder.WriteArraySize(uint32(len(o.Changes)))
+	for _, v := range o.Changes {
+		v.Encode(encoder)
+	}
+
+	return nil
+}
+
+func (o *Change) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "kind":
+			o.Kind, err = convert.Numeric[ChangeKind](decoder.ReadInt32())
+		case "path":
+			o.Path, err = decoder.ReadString()
+		case "from":
+			o.From, err = decoder.ReadNillableString()
+		case "to":
+			o.To, err = decoder.ReadNillableString()
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Change) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(4)
+	encoder.WriteString("kind")
+	encoder.WriteInt32(int32(o.Kind))
+	encoder.WriteString("path")
+	encoder.WriteString(o.Path)
+	encoder.WriteString("from")
+	encoder.WriteNillableString(o.From)
+	encoder.WriteString("to")
+	encoder.WriteNillableString(o.To)
+
+	return nil
+}
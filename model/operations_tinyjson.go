@@ -0,0 +1,551 @@
+// Code generated by tinyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	tinyjson "github.com/CosmWasm/tinyjson"
+	jlexer "github.com/CosmWasm/tinyjson/jlexer"
+	jwriter "github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ tinyjson.Marshaler
+)
+
+func tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel(in *jlexer.Lexer, out *ValidateResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]Error, 0, 1)
+					} else {
+						out.Errors = []Error{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Error
+					(v1).UnmarshalTinyJSON(in)
+					out.Errors = append(out.Errors, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel(out *jwriter.Writer, in ValidateResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Errors {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ValidateResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v ValidateResult) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ValidateResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *ValidateResult) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel(l, v)
+}
+func tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel1(in *jlexer.Lexer, out *FormatResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "source":
+			if in.IsNull() {
+				in.Skip()
+				out.Source = nil
+			} else {
+				if out.Source == nil {
+					out.Source = new(string)
+				}
+				*out.Source = string(in.String())
+			}
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]Error, 0, 1)
+					} else {
+						out.Errors = []Error{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 Error
+					(v4).UnmarshalTinyJSON(in)
+					out.Errors = append(out.Errors, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel1(out *jwriter.Writer, in FormatResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Source != nil {
+		const prefix string = ",\"source\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(*in.Source))
+	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Errors {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FormatResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v FormatResult) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FormatResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *FormatResult) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel1(l, v)
+}
+func tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel2(in *jlexer.Lexer, out *DiffResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "changes":
+			if in.IsNull() {
+				in.Skip()
+				out.Changes = nil
+			} else {
+				in.Delim('[')
+				if out.Changes == nil {
+					if !in.IsDelim(']') {
+						out.Changes = make([]Change, 0, 1)
+					} else {
+						out.Changes = []Change{}
+					}
+				} else {
+					out.Changes = (out.Changes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 Change
+					(v7).UnmarshalTinyJSON(in)
+					out.Changes = append(out.Changes, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel2(out *jwriter.Writer, in DiffResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"changes\":"
+		out.RawString(prefix[1:])
+		if in.Changes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Changes {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v DiffResult) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *DiffResult) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel2(l, v)
+}
+func tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel3(in *jlexer.Lexer, out *Change) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kind":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Kind).UnmarshalJSON(data))
+			}
+		case "path":
+			out.Path = string(in.String())
+		case "from":
+			if in.IsNull() {
+				in.Skip()
+				out.From = nil
+			} else {
+				if out.From == nil {
+					out.From = new(string)
+				}
+				*out.From = string(in.String())
+			}
+		case "to":
+			if in.IsNull() {
+				in.Skip()
+				out.To = nil
+			} else {
+				if out.To == nil {
+					out.To = new(string)
+				}
+				*out.To = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel3(out *jwriter.Writer, in Change) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.Kind).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if in.From != nil {
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(*in.From))
+	}
+	if in.To != nil {
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(*in.To))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Change) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v Change) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Change) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *Change) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel3(l, v)
+}
+func tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel4(in *jlexer.Lexer, out *ASTResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "document":
+			if in.IsNull() {
+				in.Skip()
+				out.Document = nil
+			} else {
+				if out.Document == nil {
+					out.Document = new(string)
+				}
+				*out.Document = string(in.String())
+			}
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]Error, 0, 1)
+					} else {
+						out.Errors = []Error{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 Error
+					(v10).UnmarshalTinyJSON(in)
+					out.Errors = append(out.Errors, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel4(out *jwriter.Writer, in ASTResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Document != nil {
+		const prefix string = ",\"document\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(*in.Document))
+	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.Errors {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ASTResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v ASTResult) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjsonCd06b3e6EncodeGithubComApexlangApexGoModel4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ASTResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *ASTResult) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjsonCd06b3e6DecodeGithubComApexlangApexGoModel4(l, v)
+}
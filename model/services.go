@@ -2,45 +2,47 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/apexlang/apex-go/ast"
 	"github.com/apexlang/apex-go/errors"
 	"github.com/apexlang/apex-go/location"
 	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/printer"
 	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
 )
 
 type parserImpl struct {
 	resolver Resolver
 }
 
-func NewParser(resolver Resolver) Parser {
+func NewParser(resolver Resolver) ParserService {
 	return &parserImpl{
 		resolver: resolver,
 	}
 }
 
 func (p *parserImpl) Parse(ctx context.Context, source string) (*ParserResult, error) {
-	doc, err := parser.Parse(parser.ParseParams{
-		Source: source,
-		Options: parser.ParseOptions{
-			NoSource: true,
-			Resolver: func(location, from string) (string, error) {
-				return p.resolver.Resolve(ctx, location, from)
-			},
-		},
-	})
+	doc, err := p.parse(ctx, source)
 	if err != nil {
 		return nil, err
 	}
 
-	errs := rules.Validate(doc, rules.Rules...)
+	errs := rules.ValidateContext(ctx, doc, rules.Rules...)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if len(errs) > 0 {
 		return &ParserResult{
 			Errors: convertErrors(errs),
 		}, nil
 	}
 
-	ns, errs := Convert(doc)
+	ns, errs := ConvertContext(ctx, doc)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if len(errs) > 0 {
 		return &ParserResult{
 			Errors: convertErrors(errs),
@@ -52,6 +54,92 @@ func (p *parserImpl) Parse(ctx context.Context, source string) (*ParserResult, e
 	}, nil
 }
 
+// Validate parses and validates source without converting it. Errors
+// in the spec, including syntax errors, are returned as diagnostics.
+func (p *parserImpl) Validate(ctx context.Context, source string) (*ValidateResult, error) {
+	doc, err := p.parse(ctx, source)
+	if err != nil {
+		if errs, ok := diagnostics(err); ok {
+			return &ValidateResult{Errors: errs}, nil
+		}
+		return nil, err
+	}
+
+	errs := rules.ValidateContext(ctx, doc, rules.Rules...)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &ValidateResult{
+		Errors: convertErrors(errs),
+	}, nil
+}
+
+// AST parses source, without resolving imports, and returns the
+// document as JSON.
+func (p *parserImpl) AST(ctx context.Context, src string) (*ASTResult, error) {
+	doc, err := parser.ParseContext(ctx, parser.ParseParams{
+		Source: src,
+		Options: parser.ParseOptions{
+			NoSource: true,
+		},
+	})
+	if err != nil {
+		if errs, ok := diagnostics(err); ok {
+			return &ASTResult{Errors: errs}, nil
+		}
+		return nil, err
+	}
+
+	document, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	str := string(document)
+	return &ASTResult{Document: &str}, nil
+}
+
+// Format prints source in the canonical style.
+func (p *parserImpl) Format(ctx context.Context, src string) (*FormatResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	formatted, err := printer.Format(source.NewSource("", []byte(src)))
+	if err != nil {
+		if errs, ok := diagnostics(err); ok {
+			return &FormatResult{Errors: errs}, nil
+		}
+		return nil, err
+	}
+
+	str := string(formatted)
+	return &FormatResult{Source: &str}, nil
+}
+
+// Diff returns the changes from one namespace to another.
+func (p *parserImpl) Diff(ctx context.Context, from *Namespace, to *Namespace) (*DiffResult, error) {
+	return &DiffResult{
+		Changes: Diff(from, to),
+	}, nil
+}
+
+func (p *parserImpl) parse(ctx context.Context, source string) (*ast.Document, error) {
+	return parser.ParseContext(ctx, parser.ParseParams{
+		Source: source,
+		Options: parser.ParseOptions{
+			NoSource:        true,
+			ContextResolver: p.resolver.Resolve,
+		},
+	})
+}
+
+// diagnostics returns err as diagnostics if it is an error in the spec.
+func diagnostics(err error) ([]Error, bool) {
+	if e, ok := err.(*errors.Error); ok {
+		return convertErrors([]error{e}), true
+	}
+	return nil, false
+}
+
 func convertErrors(errs []error) []Error {
 	e := make([]Error, len(errs))
 	for i, err := range errs {
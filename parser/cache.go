@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"sync"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// ImportCache holds the documents parsed for imports so that specs
+// that are parsed repeatedly, for example while watching them for
+// changes, only parse the imports that changed. It is safe for
+// concurrent use.
+type ImportCache struct {
+	mu      sync.Mutex
+	entries map[string]importCacheEntry
+}
+
+type importCacheEntry struct {
+	source string
+	doc    *ast.Document
+	// imports are the transitive imports of doc, which must resolve to
+	// the same sources for doc to be reused.
+	imports []resolvedImport
+	// usage is what parsing doc and its imports counted against the
+	// limits, which is counted again each time doc is reused.
+	usage usage
+}
+
+// resolvedImport is the source an import resolved to.
+type resolvedImport struct {
+	location string
+	from     string
+	source   string
+	// level is how deeply the import is nested below the document
+	// that has it as a transitive import, starting at 1.
+	level int
+}
+
+func NewImportCache() *ImportCache {
+	return &ImportCache{
+		entries: make(map[string]importCacheEntry),
+	}
+}
+
+// Len returns the number of cached documents.
+func (c *ImportCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// get returns the cached entry for the import named name if it was
+// parsed from src and its imports still resolve to the same sources.
+func (c *ImportCache) get(name, src string, resolver Resolver) (importCacheEntry, bool) {
+	if c == nil {
+		return importCacheEntry{}, false
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[name]
+	c.mu.Unlock()
+	if !ok || entry.source != src {
+		return importCacheEntry{}, false
+	}
+
+	for _, imp := range entry.imports {
+		current, err := resolver(imp.location, imp.from)
+		if err != nil || current != imp.source {
+			return importCacheEntry{}, false
+		}
+	}
+	return entry, true
+}
+
+func (c *ImportCache) put(name string, entry importCacheEntry) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = entry
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+func TestImportCache(t *testing.T) {
+	tests := []struct {
+		name string
+		// change is applied to the files after the first parse.
+		change func(files map[string]string)
+		// reparsed are the imports that must be parsed again.
+		reparsed []string
+		err      string
+	}{
+		{
+			name:   "unchanged",
+			change: func(files map[string]string) {},
+		},
+		{
+			name: "changed direct import",
+			change: func(files map[string]string) {
+				files["b.apex"] = strings.Replace(files["b.apex"], "b: string", "b: i64", 1)
+			},
+			reparsed: []string{"b.apex"},
+		},
+		{
+			name: "changed import of an import",
+			change: func(files map[string]string) {
+				files["c.apex"] = strings.Replace(files["c.apex"], "c: string", "c: i64", 1)
+			},
+			reparsed: []string{"b.apex", "c.apex"},
+		},
+		{
+			name: "import of an import fails to resolve",
+			change: func(files map[string]string) {
+				delete(files, "c.apex")
+			},
+			err: "c.apex not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{
+				"b.apex": "namespace \"b\"\nimport * from \"c.apex\"\ntype B { b: string }\n",
+				"c.apex": "namespace \"c\"\ntype C { c: string }\n",
+			}
+			cache := NewImportCache()
+			parse := func() (*ast.Document, error) {
+				return Parse(ParseParams{
+					Source: "namespace \"a\"\nimport * from \"b.apex\"\n",
+					Options: ParseOptions{
+						Resolver: func(location, from string) (string, error) {
+							src, ok := files[location]
+							if !ok {
+								return "", fmt.Errorf("%s not found", location)
+							}
+							return src, nil
+						},
+						ImportCache: cache,
+					},
+				})
+			}
+
+			if _, err := parse(); err != nil {
+				t.Fatal(err)
+			}
+			cached := map[string]*ast.Document{}
+			for name, entry := range cache.entries {
+				cached[name] = entry.doc
+			}
+
+			tt.change(files)
+			doc, err := parse()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for name, before := range cached {
+				reparsed := cache.entries[name].doc != before
+				want := false
+				for _, r := range tt.reparsed {
+					want = want || r == name
+				}
+				if reparsed != want {
+					t.Errorf("%s: reparsed is %v, want %v", name, reparsed, want)
+				}
+			}
+
+			// The definitions of the document reflect the current files.
+			fields := map[string]string{}
+			for _, def := range doc.Definitions {
+				if typ, ok := def.(*ast.TypeDefinition); ok {
+					fields[typ.Name.Value] = typ.Fields[0].Type.(*ast.Named).Name.Value
+				}
+			}
+			for name, want := range map[string]string{"B": fieldType(files["b.apex"]), "C": fieldType(files["c.apex"])} {
+				if fields[name] != want {
+					t.Errorf("%s: got field type %q, want %q", name, fields[name], want)
+				}
+			}
+		})
+	}
+}
+
+// fieldType returns the type of the single field in src.
+func fieldType(src string) string {
+	i := strings.LastIndex(src, ": ")
+	return strings.TrimSuffix(src[i+2:], " }\n")
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParseContext(ctx, ParseParams{Source: `namespace "test"`}); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() with a canceled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestParseNamespaceContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParseNamespaceContext(ctx, ParseParams{Source: `namespace "test"`}); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseNamespaceContext() with a canceled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestContextResolver(t *testing.T) {
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	defer cancel()
+	var resolved []string
+	resolver := func(ctx context.Context, location string, from string) (string, error) {
+		if ctx.Value(key{}) != "value" {
+			t.Errorf("resolver of %q did not receive the context", location)
+		}
+		resolved = append(resolved, location)
+		// The second import is never resolved.
+		cancel()
+		return "type A { a: string }", nil
+	}
+	_, err := ParseContext(ctx, ParseParams{
+		Source: `import * from "a.apex"
+import * from "b.apex"`,
+		Options: ParseOptions{ContextResolver: resolver},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() canceled by the resolver = %v, want %v", err, context.Canceled)
+	}
+	if !reflect.DeepEqual(resolved, []string{"a.apex"}) {
+		t.Errorf("resolved %v, want [a.apex]", resolved)
+	}
+}
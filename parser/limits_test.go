@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/source"
+)
+
+// limitTest is a spec parsed with a limit of max. Source, line and
+// column locate the limit error, and a zero line means the spec is
+// within the limit.
+type limitTest struct {
+	name   string
+	src    string
+	max    int
+	source string
+	line   uint
+	column uint
+}
+
+// limitFiles are the specs imported by the specs of limit tests.
+var limitFiles = map[string]string{
+	"a.apex":      `type A { a: string }`,
+	"b.apex":      `type B { b: string }`,
+	"chain.apex":  `import * from "nested.apex"`,
+	"deep.apex":   `type D { d: [[[string]]] }`,
+	"nested.apex": `import * from "a.apex"`,
+}
+
+// testLimit parses each spec in tests with the options of setLimit
+// and checks that it fails with the limit named limit where expected.
+// Each spec is parsed without an import cache and again with a cache
+// filled by parsing it without limits, which must not change the
+// result.
+func testLimit(t *testing.T, limit string, setLimit func(opts *ParseOptions, max int), tests []limitTest) {
+	t.Helper()
+	for _, tt := range tests {
+		for _, cached := range []bool{false, true} {
+			name := tt.name
+			if cached {
+				name += "/cached"
+			}
+			t.Run(name, func(t *testing.T) {
+				checkLimit(t, limit, setLimit, tt, cached)
+			})
+		}
+	}
+}
+
+// checkLimit parses the spec of tt, first filling an import cache if
+// cached is true, and checks the limit error.
+func checkLimit(t *testing.T, limit string, setLimit func(opts *ParseOptions, max int), tt limitTest, cached bool) {
+	opts := ParseOptions{
+		Resolver: func(location string, from string) (string, error) {
+			if src, ok := limitFiles[location]; ok {
+				return src, nil
+			}
+			return "", fmt.Errorf("%s not found", location)
+		},
+	}
+	src := source.NewSource("input.apex", []byte(tt.src))
+	if cached {
+		opts.ImportCache = NewImportCache()
+		if _, err := Parse(ParseParams{Source: src, Options: opts}); err != nil {
+			t.Fatalf("parse without limits: %v", err)
+		}
+	}
+	setLimit(&opts, tt.max)
+	_, err := Parse(ParseParams{Source: src, Options: opts})
+	if tt.line == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if !stderrors.Is(err, errors.ErrLimitExceeded) {
+		t.Fatalf("got error %v, want %v", err, errors.ErrLimitExceeded)
+	}
+	var limitErr *errors.LimitError
+	if !stderrors.As(err, &limitErr) || limitErr.Limit != limit || limitErr.Max != tt.max {
+		t.Errorf("got limit %v, want %s of %d", limitErr, limit, tt.max)
+	}
+	e := err.(*errors.Error)
+	if e.Source == nil || e.Source.Name != tt.source {
+		t.Errorf("got error in %v, want %s", e.Source, tt.source)
+	}
+	if len(e.Locations) != 1 || e.Locations[0].Line != tt.line || e.Locations[0].Column != tt.column {
+		t.Errorf("got locations %v, want %d:%d", e.Locations, tt.line, tt.column)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	testLimit(t, "MaxBytes", func(opts *ParseOptions, max int) {
+		opts.MaxBytes = max
+	}, []limitTest{
+		{name: "within", src: `type A { a: string }`, max: 20},
+		{name: "source", src: `type A { a: string }`, max: 19, source: "input.apex", line: 1, column: 1},
+		{name: "imports", src: "import * from \"a.apex\"\n", max: 30, source: "a.apex", line: 1, column: 1},
+	})
+}
+
+func TestMaxDepth(t *testing.T) {
+	testLimit(t, "MaxDepth", func(opts *ParseOptions, max int) {
+		opts.MaxDepth = max
+	}, []limitTest{
+		{name: "within", src: `type A { a: [[string]] }`, max: 8},
+		{name: "types", src: `type A { a: [[[string]]] }`, max: 3, source: "input.apex", line: 1, column: 16},
+		{name: "values", src: "type A @a(v: [[[1]]]) { a: string }", max: 3, source: "input.apex", line: 1, column: 17},
+		{name: "imports", src: `import * from "deep.apex"`, max: 3, source: "deep.apex", line: 1, column: 16},
+	})
+}
+
+func TestMaxTokens(t *testing.T) {
+	testLimit(t, "MaxTokens", func(opts *ParseOptions, max int) {
+		opts.MaxTokens = max
+	}, []limitTest{
+		{name: "within", src: `type A { a: string }`, max: 8},
+		{name: "source", src: "type A {\n  a: string\n}", max: 4, source: "input.apex", line: 2, column: 5},
+		{name: "imports", src: `import * from "a.apex"`, max: 6, source: "a.apex", line: 1, column: 9},
+	})
+}
+
+func TestMaxImports(t *testing.T) {
+	testLimit(t, "MaxImports", func(opts *ParseOptions, max int) {
+		opts.MaxImports = max
+	}, []limitTest{
+		{name: "within", src: "import * from \"a.apex\"\nimport * from \"b.apex\"", max: 2},
+		{name: "source", src: "import * from \"a.apex\"\nimport * from \"b.apex\"", max: 1, source: "input.apex", line: 2, column: 15},
+		{name: "nested", src: `import * from "chain.apex"`, max: 2, source: "nested.apex", line: 1, column: 15},
+	})
+}
+
+func TestMaxImportDepth(t *testing.T) {
+	testLimit(t, "MaxImportDepth", func(opts *ParseOptions, max int) {
+		opts.MaxImportDepth = max
+	}, []limitTest{
+		{name: "within", src: `import * from "chain.apex"`, max: 3},
+		{name: "nested", src: `import * from "chain.apex"`, max: 2, source: "nested.apex", line: 1, column: 15},
+		{name: "direct", src: `import * from "chain.apex"`, max: 1, source: "chain.apex", line: 1, column: 15},
+	})
+}
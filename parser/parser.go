@@ -17,8 +17,10 @@ limitations under the License.
 package parser
 
 import (
+	"context"
 	stderrs "errors"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/apexlang/apex-go/errors"
 	"github.com/apexlang/apex-go/lexer"
 	"github.com/apexlang/apex-go/source"
+	"github.com/apexlang/apex-go/suggest"
 )
 
 type parseFn func(parser *Parser) (ast.Node, error)
@@ -38,11 +41,6 @@ var tokenDefinitionFn map[string]parseDefinitionFn
 func init() {
 	tokenDefinitionFn = make(map[string]parseDefinitionFn)
 	{
-		// for sign
-		tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.STRING)] = parseTypeSystemDefinition
-		tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.BLOCK_STRING)] = parseTypeSystemDefinition
-		tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.NAME)] = parseTypeSystemDefinition
-		// for NAME
 		tokenDefinitionFn[lexer.NAMESPACE] = parseNamespaceDefinition
 		tokenDefinitionFn[lexer.IMPORT] = parseImportDefinition
 		tokenDefinitionFn[lexer.ALIAS] = parseAliasDefinition
@@ -55,12 +53,52 @@ func init() {
 	}
 }
 
+// Resolver returns the source of the spec imported from location by
+// the spec named from, which is empty for an unnamed source.
 type Resolver func(location string, from string) (string, error)
 
+// ContextResolver is a Resolver that receives the context passed to
+// ParseContext.
+type ContextResolver func(ctx context.Context, location string, from string) (string, error)
+
+// ImportLocation returns the name given to the source imported from
+// location by the spec named from. Relative locations starting with
+// "./" or "../" are resolved against the directory of from using
+// slash-separated paths. Other locations are returned as is.
+func ImportLocation(location, from string) string {
+	if from == "" ||
+		!(strings.HasPrefix(location, "./") || strings.HasPrefix(location, "../")) {
+		return location
+	}
+	return path.Join(path.Dir(from), location)
+}
+
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
 	Resolver   Resolver
+	// ContextResolver is used instead of Resolver if set.
+	ContextResolver ContextResolver
+	// ImportCache, if set, reuses the documents of unchanged imports
+	// across calls to Parse. It must only be shared between calls with
+	// the same options.
+	ImportCache *ImportCache
+
+	// Limits for parsing untrusted specs. Zero means no limit. Parsing
+	// stops with an error wrapping errors.ErrLimitExceeded once one is
+	// exceeded.
+
+	// MaxBytes limits the size of the source and its imports.
+	MaxBytes int
+	// MaxDepth limits the nesting of types and values.
+	MaxDepth int
+	// MaxTokens limits the tokens of the source and its imports.
+	MaxTokens int
+	// MaxImports limits the imports resolved for the source and its
+	// imports.
+	MaxImports int
+	// MaxImportDepth limits the length of import chains.
+	MaxImportDepth int
 }
 
 type ParseParams struct {
@@ -69,26 +107,51 @@ type ParseParams struct {
 }
 
 type Parser struct {
-	LexToken lexer.Lexer
-	Source   *source.Source
-	Options  ParseOptions
-	PrevEnd  uint
-	Token    lexer.Token
+	Lexer   *lexer.Lexer
+	Source  *source.Source
+	Options ParseOptions
+	PrevEnd uint
+	Token   lexer.Token
+
+	ctx context.Context
+
+	// imports are all imports resolved while parsing, including the
+	// imports of imported documents.
+	imports []resolvedImport
+
+	// usage is shared with the parsers of imports.
+	usage *usage
+	// depth is the current nesting of types and values.
+	depth int
+	// chain are the names of the sources importing this one, outermost
+	// first.
+	chain []string
+	// err is the limit error that stopped the parser.
+	err error
+}
+
+// usage counts the resources limited by ParseOptions.
+type usage struct {
+	bytes   int
+	tokens  int
+	imports int
+	// depth is the deepest nesting of types and values reached.
+	depth int
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
-	var sourceObj *source.Source
-	switch src := p.Source.(type) {
-	case *source.Source:
-		sourceObj = src
-	case []byte:
-		sourceObj = source.NewSource("", src)
-	case string:
-		sourceObj = source.NewSource("", []byte(src))
-	default:
-		return nil, stderrs.New("unexpected value for Source")
+	return ParseContext(context.Background(), p)
+}
+
+// ParseContext is like Parse but stops with ctx.Err() once ctx is
+// done. It checks ctx before each definition and each import, and
+// passes it to the ContextResolver.
+func ParseContext(ctx context.Context, p ParseParams) (*ast.Document, error) {
+	sourceObj, err := sourceOf(p.Source)
+	if err != nil {
+		return nil, err
 	}
-	parser, err := makeParser(sourceObj, p.Options)
+	parser, err := makeParser(ctx, sourceObj, p.Options, &usage{})
 	if err != nil {
 		return nil, err
 	}
@@ -99,9 +162,69 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	return doc, nil
 }
 
+// ParseNamespace parses the definitions of a spec up to its namespace
+// definition and returns it, or nil if the spec has none. Imports are
+// not resolved and the rest of the spec is not parsed, so a spec with
+// errors after its namespace definition still returns it.
+func ParseNamespace(p ParseParams) (*ast.NamespaceDefinition, error) {
+	return ParseNamespaceContext(context.Background(), p)
+}
+
+// ParseNamespaceContext is like ParseNamespace but stops with
+// ctx.Err() once ctx is done.
+func ParseNamespaceContext(ctx context.Context, p ParseParams) (*ast.NamespaceDefinition, error) {
+	sourceObj, err := sourceOf(p.Source)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := makeParser(ctx, sourceObj, p.Options, &usage{})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if err := parser.ctx.Err(); err != nil {
+			return nil, err
+		}
+		if parser.err != nil {
+			return nil, parser.err
+		}
+		if skp, err := skip(parser, lexer.EOF); err != nil {
+			return nil, err
+		} else if skp {
+			return nil, nil
+		}
+		switch parser.Token.Kind {
+		case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
+		default:
+			return nil, unexpected(parser, lexer.Token{})
+		}
+		node, err := parseTypeSystemDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		if ns, ok := node.(*ast.NamespaceDefinition); ok {
+			return ns, nil
+		}
+	}
+}
+
+// sourceOf returns the source given as the Source of ParseParams.
+func sourceOf(src interface{}) (*source.Source, error) {
+	switch src := src.(type) {
+	case *source.Source:
+		return src, nil
+	case []byte:
+		return source.NewSource("", src), nil
+	case string:
+		return source.NewSource("", []byte(src)), nil
+	default:
+		return nil, stderrs.New("unexpected value for Source")
+	}
+}
+
 // Converts a name lex token into a name parse node.
 func parseName(parser *Parser) (*ast.Name, error) {
-	token, err := expect(parser, lexer.TokenKind[lexer.NAME])
+	token, err := expect(parser, lexer.NAME)
 	if err != nil {
 		return nil, err
 	}
@@ -111,18 +234,25 @@ func parseName(parser *Parser) (*ast.Name, error) {
 	), nil
 }
 
-func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
-	lexToken := lexer.Lex(s)
-	token, err := lexToken(0)
+func makeParser(ctx context.Context, s *source.Source, opts ParseOptions, u *usage) (*Parser, error) {
+	u.bytes += len(s.Body)
+	if opts.MaxBytes > 0 && u.bytes > opts.MaxBytes {
+		return &Parser{}, errors.NewLimitError(s, 0, "MaxBytes", opts.MaxBytes,
+			fmt.Sprintf("Sources exceed the limit of %d bytes", opts.MaxBytes))
+	}
+	lex := lexer.Lex(s)
+	token, err := lex.Next()
 	if err != nil {
 		return &Parser{}, err
 	}
 	return &Parser{
-		LexToken: lexToken,
-		Source:   s,
-		Options:  opts,
-		PrevEnd:  0,
-		Token:    token,
+		Lexer:   lex,
+		Source:  s,
+		Options: opts,
+		PrevEnd: 0,
+		Token:   token,
+		ctx:     ctx,
+		usage:   u,
 	}, nil
 }
 
@@ -130,27 +260,28 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 
 func parseDocument(parser *Parser) (*ast.Document, error) {
 	var (
-		nodes []ast.Node
-		node  ast.Node
-		item  parseDefinitionFn
-		err   error
+		nodes   []ast.Node
+		origins map[ast.Node]*ast.Origin
+		node    ast.Node
+		item    parseDefinitionFn
+		err     error
 	)
 	start := parser.Token.Start
 	for {
-		if skp, err := skip(parser, lexer.TokenKind[lexer.EOF]); err != nil {
+		if err := parser.ctx.Err(); err != nil {
+			return nil, err
+		}
+		if parser.err != nil {
+			return nil, parser.err
+		}
+		if skp, err := skip(parser, lexer.EOF); err != nil {
 			return nil, err
 		} else if skp {
 			break
 		}
 		switch parser.Token.Kind {
-		case lexer.TokenKind[lexer.BRACE_L]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.BRACE_L])]
-		case lexer.TokenKind[lexer.NAME]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.NAME])]
-		case lexer.TokenKind[lexer.STRING]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.STRING])]
-		case lexer.TokenKind[lexer.BLOCK_STRING]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.BLOCK_STRING])]
+		case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
+			item = parseTypeSystemDefinition
 		default:
 			return nil, unexpected(parser, lexer.Token{})
 		}
@@ -158,24 +289,46 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 			return nil, err
 		}
 
-		if imp, ok := node.(*ast.ImportDefinition); ok && parser.Options.Resolver != nil {
-			source, err := parser.Options.Resolver(imp.From.Value, "")
-			if err != nil {
+		if imp, ok := node.(*ast.ImportDefinition); ok && parser.resolves() {
+			if err := checkImport(parser, imp); err != nil {
 				return nil, err
 			}
-			if strings.HasPrefix(source, "error:") {
-				return nil, stderrs.New(source)
+			src, err := parser.resolve(imp.From.Value, parser.Source.Name)
+			if err != nil {
+				if ctxErr := parser.ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				if _, ok := err.(*errors.Error); ok {
+					return nil, err
+				}
+				return nil, errors.NewError(
+					err.Error(),
+					[]ast.Node{imp.From},
+					"",
+					parser.Source,
+					nil,
+					err,
+				)
+			}
+			if strings.HasPrefix(src, "error:") {
+				return nil, stderrs.New(src)
 			}
-			doc, err := Parse(ParseParams{
-				Source:  source,
-				Options: parser.Options,
-			})
+			doc, err := parseImport(parser, imp, src)
 			if err != nil {
 				return nil, err
 			}
 
+			if origins == nil {
+				origins = make(map[ast.Node]*ast.Origin)
+			}
 			if imp.All {
-				nodes = append(nodes, doc.Definitions...)
+				// Copy the definitions so that changes to this document
+				// do not reach the imported one, which may be cached.
+				for _, def := range doc.Definitions {
+					c := ast.Clone(def)
+					nodes = append(nodes, c)
+					origins[c] = origin(doc, imp, def)
+				}
 			} else {
 				allDefs := make(map[string]ast.Definition)
 				for _, def := range doc.Definitions {
@@ -198,86 +351,240 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 				for _, n := range imp.Names {
 					def, ok := allDefs[n.Name.Value]
 					if !ok {
-						return nil, fmt.Errorf(
-							"could not find %q in %q", n.Name.Value, imp.From.Value)
+						candidates := make([]string, 0, len(allDefs))
+						for name := range allDefs {
+							candidates = append(candidates, name)
+						}
+						suggestions := suggest.Names(n.Name.Value, candidates)
+						err := errors.NewError(
+							fmt.Sprintf("could not find %q in %q%s",
+								n.Name.Value, imp.From.Value, suggest.Message(suggestions)),
+							[]ast.Node{n.Name},
+							"",
+							parser.Source,
+							nil,
+							nil,
+						)
+						err.Suggestions = suggestions
+						return nil, err
 					}
 					name := n.Alias
 					if name == nil {
 						name = n.Name
 					}
+					// The imported definition is copied under the
+					// imported name.
+					var renamed ast.Node
 					switch v := def.(type) {
 					case *ast.InterfaceDefinition:
-						renamedType := ast.NewInterfaceDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Annotations,
-							v.Operations,
-						)
-						nodes = append(nodes, renamedType)
-
+						c := v.Clone()
+						c.Loc, c.Name = name.Loc, name
+						renamed = c
 					case *ast.TypeDefinition:
-						renamedType := ast.NewTypeDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Interfaces,
-							v.Annotations,
-							v.Fields,
-						)
-						nodes = append(nodes, renamedType)
-
+						c := v.Clone()
+						c.Loc, c.Name = name.Loc, name
+						renamed = c
 					case *ast.EnumDefinition:
-						renamedEnum := ast.NewEnumDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Annotations,
-							v.Values,
-						)
-						nodes = append(nodes, renamedEnum)
-
+						c := v.Clone()
+						c.Loc, c.Name = name.Loc, name
+						renamed = c
 					case *ast.UnionDefinition:
-						renamedUnion := ast.NewUnionDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Annotations,
-							v.Types,
-						)
-						nodes = append(nodes, renamedUnion)
-
+						c := v.Clone()
+						c.Loc, c.Name = name.Loc, name
+						renamed = c
 					case *ast.DirectiveDefinition:
-						renamedDirective := ast.NewDirectiveDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Parameters,
-							v.Locations,
-							v.Requires,
-						)
-						nodes = append(nodes, renamedDirective)
-
+						c := v.Clone()
+						c.Loc, c.Name = name.Loc, name
+						renamed = c
 					case *ast.AliasDefinition:
-						renamedAlias := ast.NewAliasDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Type,
-							v.Annotations,
-						)
-						nodes = append(nodes, renamedAlias)
+						c := v.Clone()
+						c.Loc, c.Name = name.Loc, name
+						renamed = c
 					}
+					nodes = append(nodes, renamed)
+					origins[renamed] = origin(doc, imp, def)
 				}
 			}
 		}
 
 		nodes = append(nodes, node)
 	}
-	return ast.NewDocument(
+	if parser.err != nil {
+		return nil, parser.err
+	}
+	document := ast.NewDocument(
 		loc(parser, start),
 		nodes,
-	), nil
+	)
+	document.Origins = origins
+	return document, nil
+}
+
+// origin returns the origin of def, a definition of the document doc
+// added by imp.
+func origin(doc *ast.Document, imp *ast.ImportDefinition, def ast.Node) *ast.Origin {
+	if o, ok := doc.Origins[def]; ok {
+		return &ast.Origin{Import: imp, Definition: o.Definition}
+	}
+	return &ast.Origin{Import: imp, Definition: def}
+}
+
+// parseImport parses the source imported by imp, reusing the document
+// from the import cache if neither it nor its own imports have changed
+// since it was cached. It fails if the source is already being parsed
+// further up the import chain.
+func parseImport(parser *Parser, imp *ast.ImportDefinition, src string) (*ast.Document, error) {
+	location := imp.From.Value
+	name := ImportLocation(location, parser.Source.Name)
+	chain := append(parser.chain[:len(parser.chain):len(parser.chain)], parser.Source.Name)
+	for i, importer := range chain {
+		if importer == name {
+			return nil, errors.NewError(
+				"import cycle: "+strings.Join(append(chain[i:], name), " -> "),
+				[]ast.Node{imp.From},
+				"",
+				parser.Source,
+				nil,
+				nil,
+			)
+		}
+	}
+
+	entry, ok := parser.Options.ImportCache.get(name, src, parser.resolve)
+	if !ok || !charge(parser, chain, entry) {
+		before := *parser.usage
+		parser.usage.depth = 0
+		imported, err := makeParser(parser.ctx, source.NewSource(name, []byte(src)), parser.Options, parser.usage)
+		if err != nil {
+			return nil, err
+		}
+		imported.chain = chain
+		doc, err := parseDocument(imported)
+		if err != nil {
+			return nil, err
+		}
+		used := usage{
+			bytes:   parser.usage.bytes - before.bytes,
+			tokens:  parser.usage.tokens - before.tokens,
+			imports: parser.usage.imports - before.imports,
+			depth:   parser.usage.depth,
+		}
+		if before.depth > parser.usage.depth {
+			parser.usage.depth = before.depth
+		}
+		entry = importCacheEntry{source: src, doc: doc, imports: imported.imports, usage: used}
+		parser.Options.ImportCache.put(name, entry)
+	}
+
+	parser.imports = append(parser.imports, resolvedImport{
+		location: location,
+		from:     parser.Source.Name,
+		source:   src,
+		level:    1,
+	})
+	for _, dep := range entry.imports {
+		dep.level++
+		parser.imports = append(parser.imports, dep)
+	}
+	return entry.doc, nil
+}
+
+// charge counts a cached import, imported through chain, against the
+// limits. A cached document may have been parsed with other limits or
+// another import chain, so charge returns false and counts nothing if
+// it would exceed a limit or close an import cycle. Parsing it again
+// then reports the error where a parse without the cache would.
+func charge(parser *Parser, chain []string, entry importCacheEntry) bool {
+	opts := parser.Options
+	u := *parser.usage
+	u.bytes += entry.usage.bytes
+	u.tokens += entry.usage.tokens
+	u.imports += entry.usage.imports
+	if exceeds(opts.MaxBytes, u.bytes) ||
+		exceeds(opts.MaxTokens, u.tokens) ||
+		exceeds(opts.MaxImports, u.imports) ||
+		exceeds(opts.MaxDepth, entry.usage.depth) {
+		return false
+	}
+	for _, imp := range entry.imports {
+		if exceeds(opts.MaxImportDepth, len(chain)+imp.level) {
+			return false
+		}
+		name := ImportLocation(imp.location, imp.from)
+		for _, importer := range chain {
+			if importer == name {
+				return false
+			}
+		}
+	}
+	if entry.usage.depth > u.depth {
+		u.depth = entry.usage.depth
+	}
+	*parser.usage = u
+	return true
+}
+
+// exceeds returns true if n is above the limit max, where zero means
+// no limit.
+func exceeds(max, n int) bool {
+	return max > 0 && n > max
+}
+
+// checkImport counts imp against the import limits.
+func checkImport(parser *Parser, imp *ast.ImportDefinition) error {
+	opts := parser.Options
+	parser.usage.imports++
+	if opts.MaxImports > 0 && parser.usage.imports > opts.MaxImports {
+		return limitError(parser, imp.From.Loc, "MaxImports", opts.MaxImports,
+			fmt.Sprintf("Imports exceed the limit of %d", opts.MaxImports))
+	}
+	if opts.MaxImportDepth > 0 && len(parser.chain)+1 > opts.MaxImportDepth {
+		return limitError(parser, imp.From.Loc, "MaxImportDepth", opts.MaxImportDepth,
+			fmt.Sprintf("Imports are nested deeper than the limit of %d", opts.MaxImportDepth))
+	}
+	return nil
+}
+
+func limitError(parser *Parser, loc *ast.Location, limit string, max int, description string) error {
+	var position uint
+	if loc != nil {
+		position = loc.Start
+	}
+	return errors.NewLimitError(parser.Source, position, limit, max, description)
+}
+
+// enter increases the nesting depth, failing above MaxDepth. Each call
+// must be followed by leave.
+func enter(parser *Parser) error {
+	parser.depth++
+	if parser.depth > parser.usage.depth {
+		parser.usage.depth = parser.depth
+	}
+	if max := parser.Options.MaxDepth; max > 0 && parser.depth > max && parser.err == nil {
+		parser.err = errors.NewLimitError(parser.Source, parser.Token.Start, "MaxDepth", max,
+			fmt.Sprintf("Nesting exceeds the limit of %d levels", max))
+	}
+	return parser.err
+}
+
+func leave(parser *Parser) {
+	parser.depth--
+}
+
+func (parser *Parser) resolves() bool {
+	return parser.Options.Resolver != nil || parser.Options.ContextResolver != nil
+}
+
+// resolve returns the source imported from location, failing once the
+// context is done.
+func (parser *Parser) resolve(location, from string) (string, error) {
+	if err := parser.ctx.Err(); err != nil {
+		return "", err
+	}
+	if parser.Options.ContextResolver != nil {
+		return parser.Options.ContextResolver(parser.ctx, location, from)
+	}
+	return parser.Options.Resolver(location, from)
 }
 
 /* Implements the parsing rules in the Operations section. */
@@ -287,9 +594,9 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
  */
 func parseArguments(parser *Parser) ([]*ast.Argument, error) {
 	arguments := []*ast.Argument{}
-	if peek(parser, lexer.TokenKind[lexer.PAREN_L]) {
+	if peek(parser, lexer.PAREN_L) {
 		if iArguments, err := reverse(parser,
-			lexer.TokenKind[lexer.PAREN_L], parseArgument, lexer.TokenKind[lexer.PAREN_R],
+			lexer.PAREN_L, parseArgument, lexer.PAREN_R,
 			true,
 		); err != nil {
 			return arguments, err
@@ -312,13 +619,13 @@ func parseArgument(parser *Parser) (ast.Node, error) {
 		value ast.Value
 	)
 	start := parser.Token.Start
-	if !peek(parser, lexer.TokenKind[lexer.NAME]) {
+	if !peek(parser, lexer.NAME) {
 		name = ast.NewName(nil, "value")
 	} else {
 		if name, err = parseName(parser); err != nil {
 			return nil, err
 		}
-		if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+		if _, err = expect(parser, lexer.COLON); err != nil {
 			return nil, err
 		}
 	}
@@ -350,15 +657,19 @@ func parseArgument(parser *Parser) (ast.Node, error) {
  * EnumValue : Name but not `true`, `false` or `null`
  */
 func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
+	if err := enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	token := parser.Token
 	switch token.Kind {
-	// case lexer.TokenKind[lexer.BRACE_L]:
+	// case lexer.BRACE_L:
 	// 	return parseMap(parser, isConst)
-	case lexer.TokenKind[lexer.BRACKET_L]:
+	case lexer.BRACKET_L:
 		return parseList(parser, isConst)
-	case lexer.TokenKind[lexer.BRACE_L]:
+	case lexer.BRACE_L:
 		return parseObject(parser, isConst)
-	case lexer.TokenKind[lexer.INT]:
+	case lexer.INT:
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
@@ -370,7 +681,7 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 			loc(parser, token.Start),
 			intVal,
 		), nil
-	case lexer.TokenKind[lexer.FLOAT]:
+	case lexer.FLOAT:
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
@@ -382,9 +693,9 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 			loc(parser, token.Start),
 			floatVal,
 		), nil
-	case lexer.TokenKind[lexer.BLOCK_STRING], lexer.TokenKind[lexer.STRING]:
+	case lexer.BLOCK_STRING, lexer.STRING:
 		return parseStringLiteral(parser)
-	case lexer.TokenKind[lexer.NAME]:
+	case lexer.NAME:
 		if token.Value == "true" || token.Value == "false" {
 			if err := advance(parser); err != nil {
 				return nil, err
@@ -436,7 +747,7 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
 	}
 	values := []ast.Value{}
 	if iValues, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACKET_L], item, lexer.TokenKind[lexer.BRACKET_R],
+		lexer.BRACKET_L, item, lexer.BRACKET_R,
 		false,
 	); err != nil {
 		return nil, err
@@ -458,12 +769,12 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
  */
 func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
 	start := parser.Token.Start
-	if _, err := expect(parser, lexer.TokenKind[lexer.BRACE_L]); err != nil {
+	if _, err := expect(parser, lexer.BRACE_L); err != nil {
 		return nil, err
 	}
 	fields := []*ast.ObjectField{}
 	for {
-		if skp, err := skip(parser, lexer.TokenKind[lexer.BRACE_R]); err != nil {
+		if skp, err := skip(parser, lexer.BRACE_R); err != nil {
 			return nil, err
 		} else if skp {
 			break
@@ -490,9 +801,9 @@ func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
 		err   error
 	)
 	start := parser.Token.Start
-	if parser.Token.Kind == lexer.TokenKind[lexer.NS] ||
-		parser.Token.Kind == lexer.TokenKind[lexer.NAME] ||
-		parser.Token.Kind == lexer.TokenKind[lexer.STRING] {
+	if parser.Token.Kind == lexer.NS ||
+		parser.Token.Kind == lexer.NAME ||
+		parser.Token.Kind == lexer.STRING {
 		name = ast.NewName(
 			loc(parser, parser.Token.Start),
 			parser.Token.Value,
@@ -501,7 +812,7 @@ func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
 	} else {
 		return nil, unexpected(parser, parser.Token)
 	}
-	if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+	if _, err = expect(parser, lexer.COLON); err != nil {
 		return nil, err
 	}
 	if value, err = parseValueLiteral(parser, isConst); err != nil {
@@ -521,7 +832,7 @@ func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
  */
 func parseAnnotations(parser *Parser) ([]*ast.Annotation, error) {
 	annotations := []*ast.Annotation{}
-	for peek(parser, lexer.TokenKind[lexer.AT]) {
+	for peek(parser, lexer.AT) {
 		if annotation, err := parseAnnotation(parser); err != nil {
 			return annotations, err
 		} else {
@@ -541,7 +852,7 @@ func parseAnnotation(parser *Parser) (*ast.Annotation, error) {
 		args []*ast.Argument
 	)
 	start := parser.Token.Start
-	if _, err = expect(parser, lexer.TokenKind[lexer.AT]); err != nil {
+	if _, err = expect(parser, lexer.AT); err != nil {
 		return nil, err
 	}
 	if name, err = parseName(parser); err != nil {
@@ -566,11 +877,15 @@ func parseAnnotation(parser *Parser) (*ast.Annotation, error) {
  *   - NonNullType
  */
 func parseType(parser *Parser) (ttype ast.Type, err error) {
+	if err := enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	token := parser.Token
 	var keyType, valueType ast.Type
 	// [ String! ]!
 	switch token.Kind {
-	case lexer.TokenKind[lexer.BRACKET_L]:
+	case lexer.BRACKET_L:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
@@ -578,7 +893,7 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 			return nil, err
 		}
 		fallthrough
-	case lexer.TokenKind[lexer.BRACKET_R]:
+	case lexer.BRACKET_R:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
@@ -586,21 +901,21 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 			loc(parser, token.Start),
 			ttype,
 		)
-	case lexer.TokenKind[lexer.BRACE_L]:
+	case lexer.BRACE_L:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
 		if keyType, err = parseType(parser); err != nil {
 			return nil, err
 		}
-		if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+		if _, err = expect(parser, lexer.COLON); err != nil {
 			return nil, err
 		}
 		if valueType, err = parseType(parser); err != nil {
 			return nil, err
 		}
 		fallthrough
-	case lexer.TokenKind[lexer.BRACE_R]:
+	case lexer.BRACE_R:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
@@ -609,14 +924,14 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 			keyType,
 			valueType,
 		)
-	case lexer.TokenKind[lexer.NAME]:
+	case lexer.NAME:
 		if ttype, err = parseNamed(parser); err != nil {
 			return nil, err
 		}
 	}
 
 	// QUESTION must be executed
-	if skp, err := skip(parser, lexer.TokenKind[lexer.QUESTION]); err != nil {
+	if skp, err := skip(parser, lexer.QUESTION); err != nil {
 		return nil, err
 	} else if skp {
 		ttype = ast.NewOptional(
@@ -732,13 +1047,13 @@ func parseImportDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 
-	if peek(parser, lexer.TokenKind[lexer.STAR]) {
+	if peek(parser, lexer.STAR) {
 		all = true
 		advance(parser)
-	} else if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+	} else if peek(parser, lexer.BRACE_L) {
 		// Parameters operation
 		iImportNames, err := reverse(parser,
-			lexer.TokenKind[lexer.BRACE_L], parseImportName, lexer.TokenKind[lexer.BRACE_R],
+			lexer.BRACE_L, parseImportName, lexer.BRACE_R,
 			true,
 		)
 		if err != nil {
@@ -815,7 +1130,7 @@ func parseAliasDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
+	_, err = expect(parser, lexer.EQUALS)
 	if err != nil {
 		return nil, err
 	}
@@ -864,7 +1179,7 @@ func parseTypeDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 	iFields, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACE_L], parseFieldDefinition, lexer.TokenKind[lexer.BRACE_R],
+		lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
 		false,
 	)
 	if err != nil {
@@ -898,14 +1213,14 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 			return nil, err
 		}
 		// optional leading ampersand
-		skip(parser, lexer.TokenKind[lexer.AMP])
+		skip(parser, lexer.AMP)
 		for {
 			ttype, err := parseNamed(parser)
 			if err != nil {
 				return types, err
 			}
 			types = append(types, ttype)
-			if skipped, err := skip(parser, lexer.TokenKind[lexer.AMP]); !skipped {
+			if skipped, err := skip(parser, lexer.AMP); !skipped {
 				break
 			} else if err != nil {
 				return types, err
@@ -949,7 +1264,7 @@ func parseOperationDefinitionBody(parser *Parser, start uint, description *ast.S
 	if err != nil {
 		return nil, err
 	}
-	_, colon, err := optional(parser, lexer.TokenKind[lexer.COLON])
+	_, colon, err := optional(parser, lexer.COLON)
 	if err != nil {
 		return nil, err
 	}
@@ -998,7 +1313,7 @@ func parseFieldDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.COLON])
+	_, err = expect(parser, lexer.COLON)
 	if err != nil {
 		return nil, err
 	}
@@ -1007,7 +1322,7 @@ func parseFieldDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 	var defaultValue ast.Value
-	if skp, err := skip(parser, lexer.TokenKind[lexer.EQUALS]); err != nil {
+	if skp, err := skip(parser, lexer.EQUALS); err != nil {
 		return nil, err
 	} else if skp {
 		if defaultValue, err = parseValueLiteral(parser, true); err != nil {
@@ -1032,10 +1347,10 @@ func parseFieldDefinition(parser *Parser) (ast.Node, error) {
  * ParametersDefinition : ( ParameterDefinition+ )
  */
 func parseParameterDefs(parser *Parser, unary bool) ([]*ast.ParameterDefinition, bool, error) {
-	if peek(parser, lexer.TokenKind[lexer.PAREN_L]) {
+	if peek(parser, lexer.PAREN_L) {
 		// Parameters operation
 		iParameterDefinitions, err := reverse(parser,
-			lexer.TokenKind[lexer.PAREN_L], parseParameterDef, lexer.TokenKind[lexer.PAREN_R],
+			lexer.PAREN_L, parseParameterDef, lexer.PAREN_R,
 			true,
 		)
 		if err != nil {
@@ -1050,7 +1365,7 @@ func parseParameterDefs(parser *Parser, unary bool) ([]*ast.ParameterDefinition,
 		}
 
 		return parameterDefinitions, false, nil
-	} else if unary && peek(parser, lexer.TokenKind[lexer.BRACKET_L]) {
+	} else if unary && peek(parser, lexer.BRACKET_L) {
 		// Unary operation
 		if err := advance(parser); err != nil {
 			return nil, true, err
@@ -1060,7 +1375,7 @@ func parseParameterDefs(parser *Parser, unary bool) ([]*ast.ParameterDefinition,
 			return nil, true, err
 		}
 
-		if _, err := expect(parser, lexer.TokenKind[lexer.BRACKET_R]); err != nil {
+		if _, err := expect(parser, lexer.BRACKET_R); err != nil {
 			return nil, true, err
 		}
 
@@ -1088,7 +1403,7 @@ func parseParameterDef(parser *Parser) (ast.Node, error) {
 	if name, err = parseName(parser); err != nil {
 		return nil, err
 	}
-	if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+	if _, err = expect(parser, lexer.COLON); err != nil {
 		return nil, err
 	}
 
@@ -1107,7 +1422,7 @@ func parseParameterDef(parser *Parser) (ast.Node, error) {
 	}
 
 	var defaultValue ast.Value
-	if skp, err := skip(parser, lexer.TokenKind[lexer.EQUALS]); err != nil {
+	if skp, err := skip(parser, lexer.EQUALS); err != nil {
 		return nil, err
 	} else if skp {
 		val, err := parseConstValue(parser)
@@ -1155,7 +1470,7 @@ func parseInterfaceDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 	iOperations, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACE_L], parseOperationDefinition, lexer.TokenKind[lexer.BRACE_R],
+		lexer.BRACE_L, parseOperationDefinition, lexer.BRACE_R,
 		false,
 	)
 	if err != nil {
@@ -1197,7 +1512,7 @@ func parseUnionDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
+	_, err = expect(parser, lexer.EQUALS)
 	if err != nil {
 		return nil, err
 	}
@@ -1227,7 +1542,7 @@ func parseUnionMembers(parser *Parser) ([]ast.Type, error) {
 			return members, err
 		}
 		members = append(members, member)
-		if skp, err := skip(parser, lexer.TokenKind[lexer.PIPE]); err != nil {
+		if skp, err := skip(parser, lexer.PIPE); err != nil {
 			return nil, err
 		} else if !skp {
 			break
@@ -1258,7 +1573,7 @@ func parseEnumDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 	iEnumValueDefs, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACE_L], parseEnumValueDefinition, lexer.TokenKind[lexer.BRACE_R],
+		lexer.BRACE_L, parseEnumValueDefinition, lexer.BRACE_R,
 		false,
 	)
 	if err != nil {
@@ -1294,11 +1609,11 @@ func parseEnumValueDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
+	_, err = expect(parser, lexer.EQUALS)
 	if err != nil {
 		return nil, err
 	}
-	token, err := expect(parser, lexer.TokenKind[lexer.INT])
+	token, err := expect(parser, lexer.INT)
 	if err != nil {
 		return nil, err
 	}
@@ -1349,14 +1664,16 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	if _, err = expectKeyWord(parser, lexer.DIRECTIVE); err != nil {
 		return nil, err
 	}
-	if _, err = expect(parser, lexer.TokenKind[lexer.AT]); err != nil {
+	if _, err = expect(parser, lexer.AT); err != nil {
 		return nil, err
 	}
 	if name, err = parseName(parser); err != nil {
 		return nil, err
 	}
-	if params, _, err = parseParameterDefs(parser, false); err != nil {
-		return nil, err
+	if peek(parser, lexer.PAREN_L) {
+		if params, _, err = parseParameterDefs(parser, false); err != nil {
+			return nil, err
+		}
 	}
 	if _, err = expectKeyWord(parser, "on"); err != nil {
 		return nil, err
@@ -1395,7 +1712,7 @@ func parseDirectiveLocations(parser *Parser) ([]*ast.Name, error) {
 			locations = append(locations, name)
 		}
 
-		if hasPipe, err := skip(parser, lexer.TokenKind[lexer.PIPE]); err != nil {
+		if hasPipe, err := skip(parser, lexer.PIPE); err != nil {
 			return locations, err
 		} else if !hasPipe {
 			break
@@ -1407,7 +1724,7 @@ func parseDirectiveLocations(parser *Parser) ([]*ast.Name, error) {
 func parseDirectiveRequires(parser *Parser) ([]*ast.DirectiveRequire, error) {
 	requires := []*ast.DirectiveRequire{}
 	for {
-		token, err := expect(parser, lexer.TokenKind[lexer.AT])
+		token, err := expect(parser, lexer.AT)
 		if err != nil {
 			return requires, err
 		}
@@ -1427,7 +1744,7 @@ func parseDirectiveRequires(parser *Parser) ([]*ast.DirectiveRequire, error) {
 			locations,
 		))
 
-		if hasPipe, err := skip(parser, lexer.TokenKind[lexer.PIPE]); err != nil {
+		if hasPipe, err := skip(parser, lexer.PIPE); err != nil {
 			return requires, err
 		} else if !hasPipe {
 			break
@@ -1481,8 +1798,17 @@ func loc(parser *Parser, start uint) *ast.Location {
 
 // Moves the internal parser object to the next lexed token.
 func advance(parser *Parser) error {
+	if parser.err != nil {
+		return parser.err
+	}
+	parser.usage.tokens++
+	if max := parser.Options.MaxTokens; max > 0 && parser.usage.tokens > max {
+		parser.err = errors.NewLimitError(parser.Source, parser.Token.End, "MaxTokens", max,
+			fmt.Sprintf("Sources exceed the limit of %d tokens", max))
+		return parser.err
+	}
 	parser.PrevEnd = parser.Token.End
-	token, err := parser.LexToken(parser.PrevEnd)
+	token, err := parser.Lexer.Next()
 	if err != nil {
 		return err
 	}
@@ -1492,7 +1818,7 @@ func advance(parser *Parser) error {
 
 // lookahead retrieves the next token
 func lookahead(parser *Parser) (lexer.Token, error) {
-	return parser.LexToken(parser.Token.End)
+	return parser.Lexer.Peek()
 }
 
 // Determines if the next token is of a given kind
@@ -1537,7 +1863,7 @@ func optional(parser *Parser, kind int) (lexer.Token, bool, error) {
 // advancing the parser. Otherwise, do not change the parser state and return false.
 func expectKeyWord(parser *Parser, value string) (lexer.Token, error) {
 	token := parser.Token
-	if token.Kind == lexer.TokenKind[lexer.NAME] && token.Value == value {
+	if token.Kind == lexer.NAME && token.Value == value {
 		return token, advance(parser)
 	}
 	descp := fmt.Sprintf("Expected \"%s\", found %s", value, lexer.GetTokenDesc(token))
@@ -1546,7 +1872,7 @@ func expectKeyWord(parser *Parser, value string) (lexer.Token, error) {
 
 func optionalKeyWord(parser *Parser, value string) (lexer.Token, bool, error) {
 	token := parser.Token
-	if token.Kind == lexer.TokenKind[lexer.NAME] && token.Value == value {
+	if token.Kind == lexer.NAME && token.Value == value {
 		return token, true, advance(parser)
 	}
 	return token, false, nil
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/internal/testspecs"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, b := range testspecs.Load(f) {
+		f.Add(string(b))
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		// Every import resolves to the source itself, so imports end
+		// in an import cycle, while the limits stop deep nesting.
+		resolver := func(location string, from string) (string, error) {
+			return src, nil
+		}
+		doc, err := Parse(ParseParams{
+			Source: src,
+			Options: ParseOptions{
+				Resolver:       resolver,
+				MaxDepth:       64,
+				MaxImports:     8,
+				MaxImportDepth: 4,
+			},
+		})
+		if err == nil && doc == nil {
+			t.Fatal("no document and no error")
+		}
+	})
+}
+
+func TestDirectiveDefinitionParameters(t *testing.T) {
+	tests := []struct {
+		src    string
+		params []string
+	}{
+		{"directive @service on INTERFACE", nil},
+		{"directive @service() on INTERFACE", nil},
+		{"directive @path(value: string) on OPERATION", []string{"value"}},
+		{"directive @range(min: i32, max: i32) on FIELD | PARAMETER", []string{"min", "max"}},
+	}
+	for _, tt := range tests {
+		doc, err := Parse(ParseParams{Source: tt.src})
+		if err != nil {
+			t.Errorf("%s: %v", tt.src, err)
+			continue
+		}
+		directive := doc.Definitions[0].(*ast.DirectiveDefinition)
+		var params []string
+		for _, param := range directive.Parameters {
+			params = append(params, param.Name.Value)
+		}
+		if !reflect.DeepEqual(params, tt.params) {
+			t.Errorf("%s: got parameters %v, want %v", tt.src, params, tt.params)
+		}
+	}
+
+	if _, err := Parse(ParseParams{Source: "directive @service( on INTERFACE"}); err == nil {
+		t.Error("unterminated parameter list parsed")
+	}
+}
+
+func TestParseNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		namespace string
+		err       bool
+	}{
+		{"namespace", `namespace "a"`, "a", false},
+		{"description", `"The a namespace." namespace "a" @path("/a")`, "a", false},
+		{"after imports", "import * from \"b.apex\"\nnamespace \"a\"", "a", false},
+		{"errors after the namespace", "namespace \"a\"\ntype {", "a", false},
+		{"no namespace", "type A { a: string }", "", false},
+		{"empty", "", "", false},
+		{"errors before the namespace", "type {\nnamespace \"a\"", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns, err := ParseNamespace(ParseParams{Source: tt.src})
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v, want an error: %v", err, tt.err)
+			}
+			name := ""
+			if ns != nil {
+				name = ns.Name.Value
+			}
+			if name != tt.namespace {
+				t.Errorf("got namespace %q, want %q", name, tt.namespace)
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	body, err := os.ReadFile("../model.axdl")
+	if err != nil {
+		b.Fatal(err)
+	}
+	body = bytes.Repeat(body, 50)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(ParseParams{Source: body}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
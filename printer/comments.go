@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// comment is a # comment in the source, which the parser discards.
+type comment struct {
+	start, end uint
+	text       string
+}
+
+// scanComments returns the comments in body outside of strings.
+func scanComments(body []byte) []comment {
+	var comments []comment
+	length := uint(len(body))
+	for i := uint(0); i < length; {
+		switch {
+		case body[i] == '#':
+			end := i
+			for end < length && body[end] != '\n' && body[end] != '\r' {
+				end++
+			}
+			text := bytes.TrimRight(body[i:end], " \t")
+			comments = append(comments, comment{i, end, string(text)})
+			i = end
+		case bytes.HasPrefix(body[i:], []byte(`"""`)):
+			i += 3
+			for i < length && !bytes.HasPrefix(body[i:], []byte(`"""`)) {
+				if bytes.HasPrefix(body[i:], []byte(`\"""`)) {
+					i += 3
+				}
+				i++
+			}
+			i += 3
+		case body[i] == '"':
+			i++
+			for i < length && body[i] != '"' && body[i] != '\n' {
+				if body[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	return comments
+}
+
+// leadingComments writes the comments before node on their own lines.
+func (p *printer) leadingComments(node ast.Node) {
+	loc := node.GetLoc()
+	if loc == nil {
+		return
+	}
+	for p.next < len(p.comments) && p.comments[p.next].start < loc.Start {
+		c := p.comments[p.next]
+		p.next++
+		p.write(c.text)
+		p.newline()
+		following := loc.Start
+		if p.next < len(p.comments) && p.comments[p.next].start < following {
+			following = p.comments[p.next].start
+		}
+		if p.blankIn(c.end, following) {
+			p.newline()
+		}
+	}
+}
+
+// trailingComment writes the comment on the same line as the end of
+// node.
+func (p *printer) trailingComment(node ast.Node) {
+	loc := node.GetLoc()
+	if loc == nil || p.next >= len(p.comments) {
+		return
+	}
+	c := p.comments[p.next]
+	if c.start < loc.End || bytes.ContainsAny(p.body[loc.End:c.start], "\n\r") {
+		return
+	}
+	p.next++
+	p.write(" ", c.text)
+}
+
+// closingComments writes the comments before the closing brace at
+// end, each on a new line.
+func (p *printer) closingComments(end uint) {
+	for p.next < len(p.comments) && p.comments[p.next].start < end {
+		p.newline()
+		p.write(p.comments[p.next].text)
+		p.next++
+	}
+}
+
+// remainingComments writes the comments after the last definition
+// prev and reports whether there were any.
+func (p *printer) remainingComments(prev ast.Node) bool {
+	if p.next >= len(p.comments) {
+		return false
+	}
+	var end uint
+	if prev != nil && prev.GetLoc() != nil {
+		end = prev.GetLoc().End
+	}
+	for i, c := range p.comments[p.next:] {
+		if prev != nil || i > 0 {
+			p.newline()
+			if p.blankIn(end, c.start) {
+				p.newline()
+			}
+		}
+		p.write(c.text)
+		end = c.end
+	}
+	p.next = len(p.comments)
+	return true
+}
+
+// blankBetween reports whether the source has a blank line between a
+// and b.
+func (p *printer) blankBetween(a, b ast.Node) bool {
+	aLoc, bLoc := a.GetLoc(), b.GetLoc()
+	if aLoc == nil || bLoc == nil {
+		return false
+	}
+	return p.blankIn(aLoc.End, bLoc.Start)
+}
+
+func (p *printer) blankIn(start, end uint) bool {
+	if p.body == nil || start > end || end > uint(len(p.body)) {
+		return false
+	}
+	lines := bytes.Split(p.body[start:end], []byte("\n"))
+	for i := 1; i < len(lines)-1; i++ {
+		if len(bytes.TrimSpace(lines[i])) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,553 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package printer writes Apex specifications from their AST.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Config controls the output of the printer.
+type Config struct {
+	// Indent is written once per nesting level. The default is two
+	// spaces.
+	Indent string
+}
+
+// Fprint writes node as Apex source to w using the default
+// configuration.
+func Fprint(w io.Writer, node ast.Node) error {
+	return Config{}.Fprint(w, node)
+}
+
+// Fprint writes node as Apex source to w. node is a document, a
+// definition, a type or a value.
+func (c Config) Fprint(w io.Writer, node ast.Node) error {
+	return c.fprint(w, node, nil)
+}
+
+// Format parses s without resolving imports and prints it in the
+// canonical style. Comments are kept.
+func Format(s *source.Source) ([]byte, error) {
+	return Config{}.Format(s)
+}
+
+// Format parses s without resolving imports and prints it. Comments
+// are kept.
+func (c Config) Format(s *source.Source) ([]byte, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: s})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := c.fprint(&buf, doc, s.Body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c Config) fprint(w io.Writer, node ast.Node, body []byte) error {
+	p := printer{
+		indentText: c.Indent,
+		body:       body,
+		comments:   scanComments(body),
+	}
+	if p.indentText == "" {
+		p.indentText = "  "
+	}
+	if err := p.node(node); err != nil {
+		return err
+	}
+	_, err := w.Write(p.out.Bytes())
+	return err
+}
+
+type printer struct {
+	out        bytes.Buffer
+	indentText string
+	indent     int
+	lineStart  bool
+
+	// body and comments are set when printing parsed source. next is
+	// the first comment not yet written.
+	body     []byte
+	comments []comment
+	next     int
+}
+
+func (p *printer) node(node ast.Node) error {
+	if doc, ok := node.(*ast.Document); ok {
+		p.document(doc)
+		return nil
+	}
+	if v, ok := node.(ast.Value); ok {
+		p.value(v)
+		return nil
+	}
+	if !p.typeRef(node) && !p.definition(node) {
+		return fmt.Errorf("printer: unsupported node %s", node.GetKind())
+	}
+	return nil
+}
+
+func (p *printer) write(parts ...string) {
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		if p.lineStart {
+			for i := 0; i < p.indent; i++ {
+				p.out.WriteString(p.indentText)
+			}
+			p.lineStart = false
+		}
+		p.out.WriteString(part)
+	}
+}
+
+func (p *printer) newline() {
+	p.out.WriteByte('\n')
+	p.lineStart = true
+}
+
+func (p *printer) document(doc *ast.Document) {
+	var prev ast.Node
+	for _, def := range doc.Definitions {
+		if prev != nil {
+			p.newline()
+			if !isImport(prev) || !isImport(def) || p.blankBetween(prev, def) {
+				p.newline()
+			}
+		}
+		p.leadingComments(def)
+		p.definition(def)
+		p.trailingComment(def)
+		prev = def
+	}
+	if p.remainingComments(prev) || prev != nil {
+		p.newline()
+	}
+}
+
+func isImport(node ast.Node) bool {
+	_, ok := node.(*ast.ImportDefinition)
+	return ok
+}
+
+func (p *printer) definition(node ast.Node) bool {
+	switch d := node.(type) {
+	case *ast.NamespaceDefinition:
+		p.description(d.Description)
+		p.write("namespace ", quote(d.Name.Value))
+		p.annotations(d.Annotations)
+	case *ast.ImportDefinition:
+		p.description(d.Description)
+		p.write("import ")
+		if d.All {
+			p.write("*")
+		} else {
+			p.write("{ ")
+			for i, name := range d.Names {
+				if i > 0 {
+					p.write(", ")
+				}
+				p.write(name.Name.Value)
+				if name.Alias != nil {
+					p.write(" as ", name.Alias.Value)
+				}
+			}
+			p.write(" }")
+		}
+		p.write(" from ", quote(d.From.Value))
+		p.annotations(d.Annotations)
+	case *ast.DirectiveDefinition:
+		p.description(d.Description)
+		p.write("directive @", d.Name.Value)
+		if len(d.Parameters) > 0 {
+			p.parameters(d.Parameters)
+		}
+		p.write(" on ")
+		p.names(d.Locations)
+		for i, require := range d.Requires {
+			if i == 0 {
+				p.write(" require ")
+			} else {
+				p.write(" | ")
+			}
+			p.write("@", require.Directive.Value, " ")
+			p.names(require.Locations)
+		}
+	case *ast.AliasDefinition:
+		p.description(d.Description)
+		p.write("alias ", d.Name.Value, " = ")
+		p.typeRef(d.Type)
+		p.annotations(d.Annotations)
+	case *ast.OperationDefinition:
+		p.description(d.Description)
+		p.write("func ")
+		p.operation(d)
+	case *ast.InterfaceDefinition:
+		p.description(d.Description)
+		p.write("interface ", d.Name.Value)
+		p.annotations(d.Annotations)
+		p.write(" {")
+		p.block(d, len(d.Operations), func(i int) ast.Node {
+			return d.Operations[i]
+		}, func(i int) {
+			p.description(d.Operations[i].Description)
+			p.operation(d.Operations[i])
+		})
+	case *ast.TypeDefinition:
+		p.description(d.Description)
+		p.write("type ", d.Name.Value)
+		for i, iface := range d.Interfaces {
+			if i == 0 {
+				p.write(" implements ")
+			} else {
+				p.write(" & ")
+			}
+			p.write(iface.Name.Value)
+		}
+		p.annotations(d.Annotations)
+		p.write(" {")
+		p.block(d, len(d.Fields), func(i int) ast.Node {
+			return d.Fields[i]
+		}, func(i int) {
+			f := d.Fields[i]
+			p.description(f.Description)
+			p.valued(f.Name, f.Type, f.Default, f.Annotations)
+		})
+	case *ast.UnionDefinition:
+		p.description(d.Description)
+		p.write("union ", d.Name.Value)
+		p.annotations(d.Annotations)
+		p.write(" = ")
+		for i, t := range d.Types {
+			if i > 0 {
+				p.write(" | ")
+			}
+			p.typeRef(t)
+		}
+	case *ast.EnumDefinition:
+		p.description(d.Description)
+		p.write("enum ", d.Name.Value)
+		p.annotations(d.Annotations)
+		p.write(" {")
+		p.block(d, len(d.Values), func(i int) ast.Node {
+			return d.Values[i]
+		}, func(i int) {
+			v := d.Values[i]
+			p.description(v.Description)
+			p.write(v.Name.Value, " = ", strconv.Itoa(v.Index.Value))
+			p.annotations(v.Annotations)
+			if v.Display != nil {
+				p.write(" as ", quote(v.Display.Value))
+			}
+		})
+	case *ast.FieldDefinition:
+		p.description(d.Description)
+		p.valued(d.Name, d.Type, d.Default, d.Annotations)
+	case *ast.ParameterDefinition:
+		p.description(d.Description)
+		p.valued(d.Name, d.Type, d.Default, d.Annotations)
+	case *ast.EnumValueDefinition:
+		p.description(d.Description)
+		p.write(d.Name.Value, " = ", strconv.Itoa(d.Index.Value))
+		p.annotations(d.Annotations)
+		if d.Display != nil {
+			p.write(" as ", quote(d.Display.Value))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// block writes the n items of parent between braces, one per line.
+func (p *printer) block(parent ast.Node, n int, item func(i int) ast.Node, write func(i int)) {
+	p.indent++
+	var prev ast.Node
+	for i := 0; i < n; i++ {
+		node := item(i)
+		p.newline()
+		if prev != nil && p.blankBetween(prev, node) {
+			p.newline()
+		}
+		p.leadingComments(node)
+		write(i)
+		p.trailingComment(node)
+		prev = node
+	}
+	if loc := parent.GetLoc(); loc != nil {
+		// Comments before the closing brace.
+		p.closingComments(loc.End)
+	}
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) operation(o *ast.OperationDefinition) {
+	p.write(o.Name.Value)
+	if o.Unary && len(o.Parameters) == 1 {
+		param := o.Parameters[0]
+		p.write("[")
+		p.valued(param.Name, param.Type, param.Default, param.Annotations)
+		p.write("]")
+	} else {
+		p.parameters(o.Parameters)
+	}
+	if named, ok := o.Type.(*ast.Named); !ok || named.Name.Value != "void" {
+		p.write(": ")
+		p.typeRef(o.Type)
+	}
+	p.annotations(o.Annotations)
+}
+
+func (p *printer) parameters(params []*ast.ParameterDefinition) {
+	multiline := false
+	for _, param := range params {
+		if param.Description != nil {
+			multiline = true
+		}
+	}
+	p.write("(")
+	if multiline {
+		p.indent++
+	}
+	for i, param := range params {
+		if multiline {
+			p.newline()
+			p.description(param.Description)
+		} else if i > 0 {
+			p.write(", ")
+		}
+		p.valued(param.Name, param.Type, param.Default, param.Annotations)
+	}
+	if multiline {
+		p.indent--
+		p.newline()
+	}
+	p.write(")")
+}
+
+func (p *printer) valued(name *ast.Name, t ast.Type, value ast.Value, annotations []*ast.Annotation) {
+	p.write(name.Value, ": ")
+	p.typeRef(t)
+	if value != nil {
+		p.write(" = ")
+		p.value(value)
+	}
+	p.annotations(annotations)
+}
+
+func (p *printer) names(names []*ast.Name) {
+	for i, name := range names {
+		if i > 0 {
+			p.write(" | ")
+		}
+		p.write(name.Value)
+	}
+}
+
+func (p *printer) annotations(annotations []*ast.Annotation) {
+	for _, a := range annotations {
+		p.write(" @", a.Name.Value)
+		if len(a.Arguments) == 0 {
+			continue
+		}
+		p.write("(")
+		if len(a.Arguments) == 1 && a.Arguments[0].Name.Value == "value" && !isName(a.Arguments[0].Value) {
+			// The parser names a single unnamed argument "value".
+			p.value(a.Arguments[0].Value)
+		} else {
+			for i, arg := range a.Arguments {
+				if i > 0 {
+					p.write(", ")
+				}
+				p.write(arg.Name.Value, ": ")
+				p.value(arg.Value)
+			}
+		}
+		p.write(")")
+	}
+}
+
+// isName reports whether v is written as a name, which the parser
+// would read as the name of an argument.
+func isName(v ast.Value) bool {
+	switch v.(type) {
+	case *ast.EnumValue, *ast.BooleanValue:
+		return true
+	}
+	return false
+}
+
+func (p *printer) typeRef(t ast.Type) bool {
+	switch t := t.(type) {
+	case *ast.Named:
+		p.write(t.Name.Value)
+	case *ast.ListType:
+		p.write("[")
+		p.typeRef(t.Type)
+		p.write("]")
+	case *ast.MapType:
+		p.write("{")
+		p.typeRef(t.KeyType)
+		p.write(": ")
+		p.typeRef(t.ValueType)
+		p.write("}")
+	case *ast.Optional:
+		p.typeRef(t.Type)
+		p.write("?")
+	case *ast.Stream:
+		p.write("stream ")
+		p.typeRef(t.Type)
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *printer) value(v ast.Value) {
+	switch v := v.(type) {
+	case *ast.IntValue:
+		p.write(strconv.Itoa(v.Value))
+	case *ast.FloatValue:
+		s := strconv.FormatFloat(v.Value, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEnN") {
+			// Keep the literal a float.
+			s += ".0"
+		}
+		p.write(s)
+	case *ast.StringValue:
+		p.write(quote(v.Value))
+	case *ast.BooleanValue:
+		p.write(strconv.FormatBool(v.Value))
+	case *ast.EnumValue:
+		p.write(v.Value)
+	case *ast.ListValue:
+		p.write("[")
+		for i, item := range v.Values {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.value(item)
+		}
+		p.write("]")
+	case *ast.ObjectValue:
+		p.write("{")
+		for i, field := range v.Fields {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.write(field.Name.Value, ": ")
+			p.value(field.Value)
+		}
+		p.write("}")
+	}
+}
+
+// description writes d on its own line, as a block string if it spans
+// several lines.
+func (p *printer) description(d *ast.StringValue) {
+	if d == nil {
+		return
+	}
+	if !blockString(d.Value) {
+		p.write(quote(d.Value))
+		p.newline()
+		return
+	}
+	p.write(`"""`)
+	p.newline()
+	for _, line := range strings.Split(d.Value, "\n") {
+		p.write(strings.ReplaceAll(line, `"""`, `\"""`))
+		p.newline()
+	}
+	p.write(`"""`)
+	p.newline()
+}
+
+// blockString reports whether s survives the indentation and blank
+// line trimming of block strings.
+func blockString(s string) bool {
+	if !strings.Contains(s, "\n") || strings.Contains(s, `\"""`) {
+		return false
+	}
+	lines := strings.Split(s, "\n")
+	if isBlank(lines[0]) || isBlank(lines[len(lines)-1]) {
+		return false
+	}
+	unindented := false
+	for _, line := range lines {
+		if !isBlank(line) && line[0] != ' ' && line[0] != '\t' {
+			unindented = true
+		}
+		for _, r := range line {
+			if r < 0x20 && r != '\t' {
+				return false
+			}
+		}
+	}
+	return unindented
+}
+
+func isBlank(line string) bool {
+	return strings.Trim(line, " \t") == ""
+}
+
+// quote returns s as a string literal that the lexer reads back as s.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/printer"
+	"github.com/apexlang/apex-go/source"
+)
+
+// specs returns the paths of the specs in testdata and the conformance
+// suite that parse.
+func specs(t *testing.T) []string {
+	t.Helper()
+	var paths []string
+	for _, pattern := range []string{
+		"../model.axdl",
+		"../testdata/*.axdl",
+		"../conformance/*/*/*.apex",
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range matches {
+			if _, err := parse(t, path, read(t, path)); err == nil {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		t.Fatal("no specs found")
+	}
+	return paths
+}
+
+func read(t *testing.T, path string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func parse(t *testing.T, name string, body []byte) (*ast.Document, error) {
+	t.Helper()
+	return parser.Parse(parser.ParseParams{Source: source.NewSource(name, body)})
+}
+
+func TestFormat(t *testing.T) {
+	for _, path := range specs(t) {
+		t.Run(path, func(t *testing.T) {
+			body := read(t, path)
+			formatted, err := printer.Format(source.NewSource(path, body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Formatting is idempotent.
+			again, err := printer.Format(source.NewSource(path, formatted))
+			if err != nil {
+				t.Fatalf("formatted spec does not parse: %v\n%s", err, formatted)
+			}
+			if !bytes.Equal(again, formatted) {
+				t.Errorf("formatting again changes the spec:\n%s\nwant\n%s", again, formatted)
+			}
+
+			// Formatting keeps the meaning of the spec.
+			doc, _ := parse(t, path, body)
+			formattedDoc, err := parse(t, path, formatted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !doc.Equal(formattedDoc, true) {
+				t.Errorf("formatted spec parses to a different document:\n%s", formatted)
+			}
+		})
+	}
+}
+
+func TestFprint(t *testing.T) {
+	for _, path := range specs(t) {
+		t.Run(path, func(t *testing.T) {
+			doc, _ := parse(t, path, read(t, path))
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, doc); err != nil {
+				t.Fatal(err)
+			}
+			printed, err := parse(t, path, buf.Bytes())
+			if err != nil {
+				t.Fatalf("printed document does not parse: %v\n%s", err, buf.Bytes())
+			}
+			if !doc.Equal(printed, true) {
+				t.Errorf("printed document parses to a different document:\n%s", buf.Bytes())
+			}
+		})
+	}
+}
@@ -24,18 +24,27 @@ import (
 
 func CamelCaseDirectiveNames() ast.Visitor { return &camelCaseDirectiveNames{} }
 
-type camelCaseDirectiveNames struct{ ast.BaseVisitor }
+type camelCaseDirectiveNames struct {
+	ast.BaseVisitor
+	symbols symbols
+}
 
 func (c *camelCaseDirectiveNames) VisitDirective(context ast.Context) {
 	directive := context.Directive
 	name := directive.Name.Value
-	if name != strcase.ToLowerCamel(name) {
-		context.ReportError(
-			ValidationError(
-				directive.Name,
-				"directive %s should be camel case",
-				directive.Name.Value,
-			),
+	newName := strcase.ToLowerCamel(name)
+	if name != newName {
+		err := ValidationError(
+			directive.Name,
+			"directive %s should be camel case",
+			directive.Name.Value,
 		)
+		table := c.symbols.get(context)
+		if table.Directive(newName) == nil {
+			if refs, ok := referenceNames(table, directive); ok {
+				err.Edits = renameEdits(directive.Name, newName, refs)
+			}
+		}
+		context.ReportError(err)
 	}
 }
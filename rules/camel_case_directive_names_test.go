@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestCamelCaseDirectiveNames(t *testing.T) {
+	testRule(t, rules.CamelCaseDirectiveNames, []ruleTest{
+		{
+			name: "camel case",
+			src: `namespace "test"
+directive @myDirective on TYPE
+type A @myDirective { a: string }`,
+		},
+		{
+			name: "renames the directive and its annotations",
+			src: `namespace "test"
+directive @my_directive on TYPE | FIELD
+directive @other on TYPE
+  require @my_directive TYPE
+type A @my_directive { a: string @my_directive }`,
+			errors: []string{"directive my_directive should be camel case"},
+			fixed: `namespace "test"
+directive @myDirective on TYPE | FIELD
+directive @other on TYPE
+  require @myDirective TYPE
+type A @myDirective { a: string @myDirective }`,
+		},
+		{
+			name: "new name already defined",
+			src: `namespace "test"
+directive @my_directive on TYPE
+directive @myDirective on TYPE`,
+			errors: []string{"directive my_directive should be camel case"},
+			fixed: `namespace "test"
+directive @my_directive on TYPE
+directive @myDirective on TYPE`,
+		},
+	})
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+)
+
+// Discrimination is the requirement placed on union members so
+// that generated code can tell them apart at runtime.
+type Discrimination int
+
+const (
+	// DiscriminateNone accepts any combination of members.
+	DiscriminateNone Discrimination = iota
+	// DiscriminateScalarKinds requires that no two members share a
+	// serialized kind (string, number, bool, list or map) and that
+	// untyped members like any and raw are not used.
+	DiscriminateScalarKinds
+	// DiscriminateNamedTypes requires that every member is a distinct
+	// type or enum, as expected by the Go and TypeScript generators.
+	DiscriminateNamedTypes
+)
+
+func DiscriminatedUnionMembers(requirement Discrimination) ValidationRule {
+	return func() ast.Visitor {
+		return &discriminatedUnionMembers{requirement: requirement}
+	}
+}
+
+type discriminatedUnionMembers struct {
+	ast.BaseVisitor
+	requirement Discrimination
+}
+
+// memberKinds groups built-in types by the kind they are serialized
+// as. Members of the same kind cannot be told apart.
+var memberKinds = map[string]string{
+	"string":   "string",
+	"datetime": "string",
+	"bytes":    "string",
+	"bool":     "bool",
+	"i8":       "number",
+	"u8":       "number",
+	"i16":      "number",
+	"u16":      "number",
+	"i32":      "number",
+	"u32":      "number",
+	"i64":      "number",
+	"u64":      "number",
+	"f32":      "number",
+	"f64":      "number",
+	"any":      "",
+	"raw":      "",
+}
+
+func (r *discriminatedUnionMembers) VisitUnion(context ast.Context) {
+	switch r.requirement {
+	case DiscriminateScalarKinds:
+		r.checkScalarKinds(context)
+	case DiscriminateNamedTypes:
+		r.checkNamedTypes(context)
+	}
+}
+
+func (r *discriminatedUnionMembers) checkScalarKinds(context ast.Context) {
+	union := context.Union
+	kinds := make(map[string]string, len(union.Types))
+	for _, ut := range union.Types {
+		var kind string
+		switch v := resolveType(context, ut).(type) {
+		case *ast.ListType:
+			kind = "list"
+		case *ast.MapType:
+			kind = "map"
+		case *ast.Named:
+			var builtIn bool
+			if kind, builtIn = memberKinds[v.Name.Value]; !builtIn {
+				// Types and enums are discriminated by name.
+				continue
+			}
+			if kind == "" {
+				context.ReportError(
+					ValidationError(
+						ut,
+						"invalid member %q in union %q: %q members cannot be discriminated",
+						typeString(ut),
+						union.Name.Value,
+						v.Name.Value),
+				)
+				continue
+			}
+		default:
+			continue
+		}
+
+		if other, ambiguous := kinds[kind]; ambiguous {
+			context.ReportError(
+				ValidationError(
+					ut,
+					"ambiguous member %q in union %q: both %q and %q are serialized as a %s",
+					typeString(ut),
+					union.Name.Value,
+					other,
+					typeString(ut),
+					kind),
+			)
+			continue
+		}
+		kinds[kind] = typeString(ut)
+	}
+}
+
+func (r *discriminatedUnionMembers) checkNamedTypes(context ast.Context) {
+	union := context.Union
+	definitions := make(map[ast.Definition]string, len(union.Types))
+	for _, ut := range union.Types {
+		if _, ok := ut.(*ast.Optional); ok {
+			// Error reported by ValidUnionMembers
+			continue
+		}
+		if named, ok := resolveType(context, ut).(*ast.Named); ok {
+			_, builtIn := builtInTypeNames[named.Name.Value]
+			if _, known := context.Named[named.Name.Value]; !builtIn && !known {
+				// Error reported by KnownTypes
+				continue
+			}
+		}
+		definition := resolveDefinition(context, ut)
+		switch definition.(type) {
+		case *ast.TypeDefinition, *ast.EnumDefinition:
+		case *ast.UnionDefinition:
+			// Error reported by ValidUnionMembers
+			continue
+		default:
+			context.ReportError(
+				ValidationError(
+					ut,
+					"invalid member %q in union %q: members must be types or enums",
+					typeString(ut),
+					union.Name.Value),
+			)
+			continue
+		}
+
+		if other, duplicate := definitions[definition]; duplicate {
+			if other != typeString(ut) {
+				context.ReportError(
+					ValidationError(
+						ut,
+						"ambiguous member %q in union %q: %q refers to the same type",
+						typeString(ut),
+						union.Name.Value,
+						other),
+				)
+			}
+			// Otherwise reported by UniqueUnionMembers
+			continue
+		}
+		definitions[definition] = typeString(ut)
+	}
+}
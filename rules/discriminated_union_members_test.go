@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestDiscriminatedUnionMembers(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		testRule(t, rules.DiscriminatedUnionMembers(rules.DiscriminateNone), []ruleTest{
+			{
+				name: "any members",
+				src: `namespace "test"
+union U = string | datetime | any | i32 | f64`,
+			},
+		})
+	})
+
+	t.Run("scalar kinds", func(t *testing.T) {
+		testRule(t, rules.DiscriminatedUnionMembers(rules.DiscriminateScalarKinds), []ruleTest{
+			{
+				name: "distinct kinds",
+				src: `namespace "test"
+type A { a: string }
+type B { b: string }
+union U = A | B | string | i64 | bool | [A] | {string: B}`,
+			},
+			{
+				name: "shared and untyped kinds",
+				src: `namespace "test"
+alias Time = datetime
+union U = string | Time | i32 | f64 | [string] | [i64] | any`,
+				errors: []string{
+					`ambiguous member "Time" in union "U": both "string" and "Time" are serialized as a string`,
+					`ambiguous member "f64" in union "U": both "i32" and "f64" are serialized as a number`,
+					`ambiguous member "[i64]" in union "U": both "[string]" and "[i64]" are serialized as a list`,
+					`invalid member "any" in union "U": "any" members cannot be discriminated`,
+				},
+			},
+		})
+	})
+
+	t.Run("named types", func(t *testing.T) {
+		testRule(t, rules.DiscriminatedUnionMembers(rules.DiscriminateNamedTypes), []ruleTest{
+			{
+				name: "types and enums",
+				src: `namespace "test"
+type A { a: string }
+enum E { X = 0 }
+alias B = A
+union U = B | E`,
+			},
+			{
+				name: "scalars, containers and aliases of the same type",
+				src: `namespace "test"
+type A { a: string }
+alias B = A
+union U = A | string | [A] | B`,
+				errors: []string{
+					`invalid member "string" in union "U": members must be types or enums`,
+					`invalid member "[A]" in union "U": members must be types or enums`,
+					`ambiguous member "B" in union "U": "A" refers to the same type`,
+				},
+			},
+		})
+	})
+}
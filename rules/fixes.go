@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
+)
+
+// renameEdits returns the edits that rename name and each reference
+// in refs to newName. Only names that still match their source text
+// and live in the same source as name are edited, so references in
+// imported documents are left untouched. It returns nil if name has
+// no source to edit.
+func renameEdits(name *ast.Name, newName string, refs []*ast.Name) []source.TextEdit {
+	edit, ok := nameEdit(name, newName, nil)
+	if !ok {
+		return nil
+	}
+	edits := []source.TextEdit{edit}
+	for _, ref := range refs {
+		if ref == name {
+			continue
+		}
+		if edit, ok := nameEdit(ref, newName, name.Loc.Source); ok {
+			edits = append(edits, edit)
+		}
+	}
+	return edits
+}
+
+// nameEdit returns an edit replacing name with newName. If src is not
+// nil, the name must be located in src.
+func nameEdit(name *ast.Name, newName string, src *source.Source) (source.TextEdit, bool) {
+	loc := name.Loc
+	if src == nil && loc != nil {
+		src = loc.Source
+	}
+	if text, ok := sourceText(loc, src); !ok || text != name.Value {
+		return source.TextEdit{}, false
+	}
+	return source.TextEdit{
+		Start:   loc.Start,
+		End:     loc.End,
+		NewText: newName,
+	}, true
+}
+
+// sourceText returns the text at loc, which must be located in src and
+// lie within its body. Edits are only made where the text is what the
+// rule expects, so they cannot apply to another file or stale offsets.
+func sourceText(loc *ast.Location, src *source.Source) (string, bool) {
+	if loc == nil || src == nil || loc.Source != src {
+		return "", false
+	}
+	body := src.Body
+	if loc.End > uint(len(body)) || loc.Start > loc.End {
+		return "", false
+	}
+	return string(body[loc.Start:loc.End]), true
+}
+
+// documentSource returns the source of the document being validated,
+// which is the file fixes apply to.
+func documentSource(context ast.Context) *source.Source {
+	if loc := context.Document.GetLoc(); loc != nil {
+		return loc.Source
+	}
+	return nil
+}
+
+// symbols builds the symbol table of the document being validated
+// the first time a rule needs it.
+type symbols struct {
+	table *ast.SymbolTable
+}
+
+func (s *symbols) get(context ast.Context) *ast.SymbolTable {
+	if s.table == nil {
+		s.table = ast.NewSymbolTable(context.Document)
+	}
+	return s.table
+}
+
+// referenceNames returns the names in the references of the symbol
+// defined by def: the names of named types, annotations and directive
+// requires. It returns false if def is not the definition of its name,
+// as with a duplicate, since renaming it would be ambiguous.
+func referenceNames(table *ast.SymbolTable, def ast.Definition) ([]*ast.Name, bool) {
+	symbol := table.Lookup(def)
+	if symbol == nil {
+		return nil, false
+	}
+	names := make([]*ast.Name, 0, len(symbol.References))
+	for _, ref := range symbol.References {
+		switch r := ref.(type) {
+		case *ast.Named:
+			names = append(names, r.Name)
+		case *ast.Annotation:
+			names = append(names, r.Name)
+		case *ast.Name:
+			names = append(names, r)
+		}
+	}
+	return names, true
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/suggest"
 )
 
 func KnownTypes() ast.Visitor { return &knownTypes{} }
@@ -43,17 +44,36 @@ var builtInTypeNames = map[string]struct{}{
 	"datetime": {},
 	"bytes":    {},
 	"any":      {},
-	"value":    {},
 	"raw":      {},
 }
 
+// typeNames returns the built-in and defined type names, which
+// are the candidates for suggestions.
+func typeNames(context ast.Context) []string {
+	names := make([]string, 0, len(builtInTypeNames)+len(context.Named))
+	for name := range builtInTypeNames {
+		names = append(names, name)
+	}
+	for name := range context.Named {
+		names = append(names, name)
+	}
+	return names
+}
+
 func (c *knownTypes) VisitAlias(context ast.Context) {
 	alias := context.Alias
 	c.checkType(context, `alias`, alias.Name.Value, alias.Type)
 }
 
 func (c *knownTypes) VisitOperationAfter(context ast.Context) {
-	oper := context.Operation
+	c.checkReturn(context, context.Operation)
+}
+
+func (c *knownTypes) VisitFunctionAfter(context ast.Context) {
+	c.checkReturn(context, context.Function)
+}
+
+func (c *knownTypes) checkReturn(context ast.Context, oper *ast.OperationDefinition) {
 	// "void" is a special case for operations without a return.
 	if named, ok := oper.Type.(*ast.Named); ok && named.Name.Value == "void" {
 		return
@@ -78,7 +98,7 @@ func (c *knownTypes) VisitParameter(context ast.Context) {
 	)
 }
 
-func (c *knownTypes) VisitField(context ast.Context) {
+func (c *knownTypes) VisitTypeField(context ast.Context) {
 	t := context.Type
 	field := context.Field
 	c.checkType(
@@ -126,28 +146,32 @@ func (c *knownTypes) checkType(
 		if first == strings.ToLower(first) {
 			// Check for built-in types
 			if _, ok := builtInTypeNames[name]; !ok {
-				context.ReportError(
-					ValidationError(
-						v,
-						"invalid built-in type %q for %s in %q",
-						name,
-						forName,
-						parentName,
-					),
+				suggestions := suggest.Names(name, typeNames(context))
+				err := ValidationError(
+					v,
+					"invalid built-in type %q for %s in %q%s",
+					name,
+					forName,
+					parentName,
+					suggest.Message(suggestions),
 				)
+				err.Suggestions = suggestions
+				context.ReportError(err)
 			}
 		} else {
 			// Check against defined types
 			if _, ok := context.Named[name]; !ok {
-				context.ReportError(
-					ValidationError(
-						v,
-						"unknown type %q for %s in %q",
-						name,
-						forName,
-						parentName,
-					),
+				suggestions := suggest.Names(name, typeNames(context))
+				err := ValidationError(
+					v,
+					"unknown type %q for %s in %q%s",
+					name,
+					forName,
+					parentName,
+					suggest.Message(suggestions),
 				)
+				err.Suggestions = suggestions
+				context.ReportError(err)
 			}
 		}
 
@@ -160,5 +184,8 @@ func (c *knownTypes) checkType(
 
 	case *ast.ListType:
 		c.checkType(context, forName, parentName, v.Type)
+
+	case *ast.Stream:
+		c.checkType(context, forName, parentName, v.Type)
 	}
 }
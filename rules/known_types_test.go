@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestKnownTypes(t *testing.T) {
+	testRule(t, rules.KnownTypes, []ruleTest{
+		{
+			name: "known types",
+			src: `namespace "test"
+type User { name: string groups: [Group] }
+type Group { id: u64 }`,
+		},
+		{
+			name: "unknown types with suggestions",
+			src: `namespace "test"
+type User { name: strng groups: [Grup] owner: Owner }
+type Group { id: u64 }`,
+			errors: []string{
+				`invalid built-in type "strng" for field "name" in "User"; did you mean "string"?`,
+				`unknown type "Grup" for field "groups" in "User"; did you mean "Group"?`,
+				`unknown type "Owner" for field "owner" in "User"`,
+			},
+		},
+	})
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package rules
 
 import (
+	"strconv"
+
 	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
 )
 
 func NamespaceFirst() ast.Visitor { return &namespaceFirst{} }
@@ -26,6 +29,7 @@ type namespaceFirst struct{ ast.BaseVisitor }
 
 func (c *namespaceFirst) VisitNamespace(context ast.Context) {
 	pos := 0
+	var first ast.Node
 	for _, def := range context.Document.Definitions {
 		switch v := def.(type) {
 		case *ast.ImportDefinition, *ast.DirectiveDefinition:
@@ -34,14 +38,61 @@ func (c *namespaceFirst) VisitNamespace(context ast.Context) {
 			if pos == 0 {
 				return
 			}
-			context.ReportError(
-				ValidationError(
-					v,
-					"namespace must be defined before any other definition",
-				),
+			err := ValidationError(
+				v,
+				"namespace must be defined before any other definition",
 			)
+			err.Edits = moveEdits(v, v.Name, first, documentSource(context))
+			context.ReportError(err)
 		default:
+			if pos == 0 {
+				first = def
+			}
 			pos++
 		}
 	}
 }
+
+// moveEdits returns the edits that move the text of node, which is
+// named name, in front of the text of before. It returns nil unless both
+// are located in src, before comes first and name is found in the text
+// of node, written bare or quoted.
+func moveEdits(node ast.Node, name *ast.Name, before ast.Node, src *source.Source) []source.TextEdit {
+	loc, beforeLoc := node.GetLoc(), before.GetLoc()
+	if _, ok := sourceText(loc, src); !ok {
+		return nil
+	}
+	if _, ok := sourceText(beforeLoc, src); !ok || beforeLoc.Start >= loc.Start {
+		return nil
+	}
+	if name.Loc == nil || name.Loc.Start < loc.Start || name.Loc.End > loc.End {
+		return nil
+	}
+	if text, ok := sourceText(name.Loc, src); !ok ||
+		text != name.Value && text != strconv.Quote(name.Value) {
+		return nil
+	}
+	body := src.Body
+
+	// Remove the whitespace following the node along with it.
+	end := loc.End
+	for end < uint(len(body)) && isSpace(body[end]) {
+		end++
+	}
+
+	return []source.TextEdit{
+		{
+			Start:   beforeLoc.Start,
+			End:     beforeLoc.Start,
+			NewText: string(body[loc.Start:loc.End]) + "\n\n",
+		},
+		{
+			Start: loc.Start,
+			End:   end,
+		},
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestNamespaceFirst(t *testing.T) {
+	const misplaced = `type A { a: string }
+
+namespace "test"
+`
+	namespace := func(doc *ast.Document) *ast.NamespaceDefinition {
+		return doc.Definitions[1].(*ast.NamespaceDefinition)
+	}
+	testRule(t, rules.NamespaceFirst, []ruleTest{
+		{
+			name: "namespace first",
+			src: `import * from "other"
+namespace "test"
+type A { a: string }`,
+		},
+		{
+			name:   "namespace after a type",
+			src:    misplaced,
+			errors: []string{"namespace must be defined before any other definition"},
+			fixed: `namespace "test"
+
+type A { a: string }
+
+`,
+		},
+		{
+			name: "namespace from another source",
+			src:  misplaced,
+			edit: func(doc *ast.Document) {
+				ns := namespace(doc)
+				loc := *ns.Loc
+				loc.Source = source.NewSource("other.apex", loc.Source.Body)
+				ns.Loc = &loc
+			},
+			errors: []string{"namespace must be defined before any other definition"},
+			fixed:  misplaced,
+		},
+		{
+			name: "namespace name not in the source",
+			src:  misplaced,
+			edit: func(doc *ast.Document) {
+				namespace(doc).Name.Value = "renamed"
+			},
+			errors: []string{"namespace must be defined before any other definition"},
+			fixed:  misplaced,
+		},
+	})
+}
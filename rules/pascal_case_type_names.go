@@ -18,42 +18,24 @@ package rules
 
 import (
 	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
 	"github.com/iancoleman/strcase"
 )
 
 func PascalCaseTypeNames() ast.Visitor { return &pascelCaseTypeNames{} }
 
-type pascelCaseTypeNames struct{ ast.BaseVisitor }
-
-func (r *pascelCaseTypeNames) VisitNamespace(context ast.Context) {
-	pos := 0
-	for _, def := range context.Document.Definitions {
-		switch v := def.(type) {
-		case *ast.ImportDefinition, *ast.DirectiveDefinition:
-			// Ignore the position
-		case *ast.NamespaceDefinition:
-			if pos == 0 {
-				return
-			}
-			context.ReportError(
-				ValidationError(
-					v,
-					"namespace must be defined before any other definition",
-				),
-			)
-		default:
-			pos++
-		}
-	}
+type pascelCaseTypeNames struct {
+	ast.BaseVisitor
+	symbols symbols
 }
 
 func (r *pascelCaseTypeNames) VisitAlias(context ast.Context) {
 	alias := context.Alias
 	name := alias.Name.Value
 	if name != strcase.ToCamel(name) {
-		context.ReportError(
-			ValidationError(alias.Name, "alias %q should be pascal case", name),
-		)
+		err := ValidationError(alias.Name, "alias %q should be pascal case", name)
+		err.Edits = r.renameEdits(context, alias, alias.Name)
+		context.ReportError(err)
 	}
 }
 
@@ -61,9 +43,9 @@ func (r *pascelCaseTypeNames) VisitType(context ast.Context) {
 	t := context.Type
 	name := t.Name.Value
 	if name != strcase.ToCamel(name) {
-		context.ReportError(
-			ValidationError(t.Name, "type %q should be pascal case", name),
-		)
+		err := ValidationError(t.Name, "type %q should be pascal case", name)
+		err.Edits = r.renameEdits(context, t, t.Name)
+		context.ReportError(err)
 	}
 }
 
@@ -71,9 +53,9 @@ func (r *pascelCaseTypeNames) VisitEnum(context ast.Context) {
 	enumDef := context.Enum
 	name := enumDef.Name.Value
 	if name != strcase.ToCamel(name) {
-		context.ReportError(
-			ValidationError(enumDef.Name, "enum %q should be pascal case", name),
-		)
+		err := ValidationError(enumDef.Name, "enum %q should be pascal case", name)
+		err.Edits = r.renameEdits(context, enumDef, enumDef.Name)
+		context.ReportError(err)
 	}
 }
 
@@ -81,8 +63,21 @@ func (r *pascelCaseTypeNames) VisitUnion(context ast.Context) {
 	union := context.Union
 	name := union.Name.Value
 	if name != strcase.ToCamel(name) {
-		context.ReportError(
-			ValidationError(union.Name, "union %q should be pascal case", name),
-		)
+		err := ValidationError(union.Name, "union %q should be pascal case", name)
+		err.Edits = r.renameEdits(context, union, union.Name)
+		context.ReportError(err)
+	}
+}
+
+func (r *pascelCaseTypeNames) renameEdits(context ast.Context, def ast.Definition, name *ast.Name) []source.TextEdit {
+	table := r.symbols.get(context)
+	newName := strcase.ToCamel(name.Value)
+	if table.Type(newName) != nil {
+		return nil
+	}
+	refs, ok := referenceNames(table, def)
+	if !ok {
+		return nil
 	}
+	return renameEdits(name, newName, refs)
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestPascalCaseTypeNames(t *testing.T) {
+	const misnamed = `namespace "test"
+type my_type { next: my_type? list: [my_type] }
+func get(id: string): my_type
+`
+	testRule(t, rules.PascalCaseTypeNames, []ruleTest{
+		{
+			name: "pascal case",
+			src: `namespace "test"
+alias Id = string
+type MyType { id: Id }
+enum Level { LOW = 0 }
+union Value = string | MyType`,
+		},
+		{
+			name:   "renames the type and its references",
+			src:    misnamed,
+			errors: []string{`type "my_type" should be pascal case`},
+			fixed: `namespace "test"
+type MyType { next: MyType? list: [MyType] }
+func get(id: string): MyType
+`,
+		},
+		{
+			name: "each kind of definition",
+			src: `namespace "test"
+alias my_id = string
+enum my_level { LOW = 0 }
+union my_value = string | my_id`,
+			errors: []string{
+				`alias "my_id" should be pascal case`,
+				`union "my_value" should be pascal case`,
+				`enum "my_level" should be pascal case`,
+			},
+			fixed: `namespace "test"
+alias MyId = string
+enum MyLevel { LOW = 0 }
+union MyValue = string | MyId`,
+		},
+		{
+			name: "new name already defined",
+			src: `namespace "test"
+type my_type { a: string }
+type MyType { b: my_type }`,
+			errors: []string{`type "my_type" should be pascal case`},
+			fixed: `namespace "test"
+type my_type { a: string }
+type MyType { b: my_type }`,
+		},
+		{
+			name: "new name is an interface",
+			src: `namespace "test"
+interface MyType { get(): string }
+type my_type { a: string }`,
+			errors: []string{`type "my_type" should be pascal case`},
+			fixed: `namespace "test"
+interface MyType { get(): string }
+type my_type { a: string }`,
+		},
+		{
+			name: "duplicate definition",
+			src: `namespace "test"
+type my_type { a: string }
+type my_type { b: my_type }`,
+			errors: []string{
+				`type "my_type" should be pascal case`,
+				`type "my_type" should be pascal case`,
+			},
+			fixed: `namespace "test"
+type MyType { a: string }
+type my_type { b: MyType }`,
+		},
+		{
+			name: "references in another source are left alone",
+			src:  misnamed,
+			edit: func(doc *ast.Document) {
+				oper := doc.Definitions[2].(*ast.OperationDefinition)
+				name := oper.Type.(*ast.Named).Name
+				loc := *name.Loc
+				loc.Source = source.NewSource("other.apex", loc.Source.Body)
+				name.Loc = &loc
+			},
+			errors: []string{`type "my_type" should be pascal case`},
+			fixed: `namespace "test"
+type MyType { next: MyType? list: [MyType] }
+func get(id: string): my_type
+`,
+		},
+		{
+			name: "name changed after parsing",
+			src:  misnamed,
+			edit: func(doc *ast.Document) {
+				doc.Definitions[1].(*ast.TypeDefinition).Name.Value = "other_type"
+			},
+			errors: []string{`type "other_type" should be pascal case`},
+			fixed:  misnamed,
+		},
+	})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+// Profile selects and configures the validation rules that depend on
+// how a spec is going to be used, such as the code generators it targets.
+type Profile struct {
+	// UnionDiscrimination is the requirement placed on union members.
+	UnionDiscrimination Discrimination
+	// Targets are the languages whose reserved words cannot be
+	// used as names.
+	Targets []Target
+}
+
+// DefaultProfile applies the core rules only. Union discrimination and
+// reserved words are opt-in, so specs that validated before still do.
+var DefaultProfile = Profile{}
+
+// Rules returns the core validation rules followed by the rules
+// configured by the profile.
+func (p Profile) Rules() []ValidationRule {
+	rules := make([]ValidationRule, len(coreRules), len(coreRules)+2)
+	copy(rules, coreRules)
+	if p.UnionDiscrimination != DiscriminateNone {
+		rules = append(rules, DiscriminatedUnionMembers(p.UnionDiscrimination))
+	}
+	if len(p.Targets) > 0 {
+		rules = append(rules, ReservedWordNames(p.Targets...))
+	}
+	return rules
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// Target is a language that code is generated for.
+type Target struct {
+	// Name is the display name of the language.
+	Name string
+	// Key is the argument of the rename annotation that sets the
+	// name used for this language.
+	Key string
+	// Keywords are the words that cannot be used as identifiers.
+	Keywords map[string]struct{}
+}
+
+// RenameAnnotation resolves a reserved word clash when it has an argument
+// for the target's key or a single unnamed argument for all targets.
+const RenameAnnotation = "rename"
+
+var (
+	TargetGo = Target{
+		Name: "Go",
+		Key:  "go",
+		Keywords: words(
+			"break", "case", "chan", "const", "continue", "default", "defer",
+			"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+			"interface", "map", "package", "range", "return", "select",
+			"struct", "switch", "type", "var",
+		),
+	}
+	TargetTypeScript = Target{
+		Name: "TypeScript",
+		Key:  "typescript",
+		Keywords: words(
+			"await", "break", "case", "catch", "class", "const", "continue",
+			"debugger", "default", "delete", "do", "else", "enum", "export",
+			"extends", "false", "finally", "for", "function", "if",
+			"implements", "import", "in", "instanceof", "interface", "let",
+			"new", "null", "package", "private", "protected", "public",
+			"return", "static", "super", "switch", "this", "throw", "true",
+			"try", "typeof", "var", "void", "while", "with", "yield",
+		),
+	}
+	TargetRust = Target{
+		Name: "Rust",
+		Key:  "rust",
+		Keywords: words(
+			"abstract", "as", "async", "await", "become", "box", "break",
+			"const", "continue", "crate", "do", "dyn", "else", "enum",
+			"extern", "false", "final", "fn", "for", "if", "impl", "in",
+			"let", "loop", "macro", "match", "mod", "move", "mut", "override",
+			"priv", "pub", "ref", "return", "self", "Self", "static",
+			"struct", "super", "trait", "true", "try", "type", "typeof",
+			"unsafe", "unsized", "use", "virtual", "where", "while", "yield",
+		),
+	}
+	TargetPython = Target{
+		Name: "Python",
+		Key:  "python",
+		Keywords: words(
+			"False", "None", "True", "and", "as", "assert", "async", "await",
+			"break", "class", "continue", "def", "del", "elif", "else",
+			"except", "finally", "for", "from", "global", "if", "import",
+			"in", "is", "lambda", "nonlocal", "not", "or", "pass", "raise",
+			"return", "try", "while", "with", "yield",
+		),
+	}
+	TargetJava = Target{
+		Name: "Java",
+		Key:  "java",
+		Keywords: words(
+			"abstract", "assert", "boolean", "break", "byte", "case", "catch",
+			"char", "class", "const", "continue", "default", "do", "double",
+			"else", "enum", "extends", "false", "final", "finally", "float",
+			"for", "goto", "if", "implements", "import", "instanceof", "int",
+			"interface", "long", "native", "new", "null", "package",
+			"private", "protected", "public", "return", "short", "static",
+			"strictfp", "super", "switch", "synchronized", "this", "throw",
+			"throws", "transient", "true", "try", "void", "volatile", "while",
+		),
+	}
+	TargetCSharp = Target{
+		Name: "C#",
+		Key:  "csharp",
+		Keywords: words(
+			"abstract", "as", "base", "bool", "break", "byte", "case", "catch",
+			"char", "checked", "class", "const", "continue", "decimal",
+			"default", "delegate", "do", "double", "else", "enum", "event",
+			"explicit", "extern", "false", "finally", "fixed", "float", "for",
+			"foreach", "goto", "if", "implicit", "in", "int", "interface",
+			"internal", "is", "lock", "long", "namespace", "new", "null",
+			"object", "operator", "out", "override", "params", "private",
+			"protected", "public", "readonly", "ref", "return", "sbyte",
+			"sealed", "short", "sizeof", "stackalloc", "static", "string",
+			"struct", "switch", "this", "throw", "true", "try", "typeof",
+			"uint", "ulong", "unchecked", "unsafe", "ushort", "using",
+			"virtual", "void", "volatile", "while",
+		),
+	}
+)
+
+// Targets are the known targets by key.
+var Targets = map[string]Target{
+	TargetGo.Key:         TargetGo,
+	TargetTypeScript.Key: TargetTypeScript,
+	TargetRust.Key:       TargetRust,
+	TargetPython.Key:     TargetPython,
+	TargetJava.Key:       TargetJava,
+	TargetCSharp.Key:     TargetCSharp,
+}
+
+func words(values ...string) map[string]struct{} {
+	m := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		m[value] = struct{}{}
+	}
+	return m
+}
+
+func ReservedWordNames(targets ...Target) ValidationRule {
+	return func() ast.Visitor {
+		return &reservedWordNames{targets: targets}
+	}
+}
+
+type reservedWordNames struct {
+	ast.BaseVisitor
+	targets []Target
+}
+
+func (r *reservedWordNames) VisitAlias(context ast.Context) {
+	alias := context.Alias
+	r.check(context, alias.Name, alias.Annotations, "alias", "")
+}
+
+func (r *reservedWordNames) VisitType(context ast.Context) {
+	t := context.Type
+	r.check(context, t.Name, t.Annotations, "type", "")
+}
+
+func (r *reservedWordNames) VisitTypeField(context ast.Context) {
+	field := context.Field
+	r.check(context, field.Name, field.Annotations,
+		"field", fmt.Sprintf(" in type %q", context.Type.Name.Value))
+}
+
+func (r *reservedWordNames) VisitUnion(context ast.Context) {
+	union := context.Union
+	r.check(context, union.Name, union.Annotations, "union", "")
+}
+
+func (r *reservedWordNames) VisitEnum(context ast.Context) {
+	enumDef := context.Enum
+	r.check(context, enumDef.Name, enumDef.Annotations, "enum", "")
+}
+
+func (r *reservedWordNames) VisitEnumValue(context ast.Context) {
+	enumValue := context.EnumValue
+	r.check(context, enumValue.Name, enumValue.Annotations,
+		"value", fmt.Sprintf(" in enum %q", context.Enum.Name.Value))
+}
+
+func (r *reservedWordNames) VisitInterface(context ast.Context) {
+	iface := context.Interface
+	r.check(context, iface.Name, iface.Annotations, "interface", "")
+}
+
+func (r *reservedWordNames) VisitOperation(context ast.Context) {
+	oper := context.Operation
+	r.check(context, oper.Name, oper.Annotations,
+		"operation", fmt.Sprintf(" in interface %q", context.Interface.Name.Value))
+}
+
+func (r *reservedWordNames) VisitFunction(context ast.Context) {
+	function := context.Function
+	r.check(context, function.Name, function.Annotations, "func", "")
+}
+
+func (r *reservedWordNames) VisitParameter(context ast.Context) {
+	param := context.Parameter
+	r.check(context, param.Name, param.Annotations,
+		"parameter", fmt.Sprintf(" in %q", context.Operation.Name.Value))
+}
+
+func (r *reservedWordNames) check(
+	context ast.Context,
+	name *ast.Name,
+	annotations []*ast.Annotation,
+	what string,
+	parent string,
+) {
+	var rename *ast.Annotation
+	for _, a := range annotations {
+		if a.Name.Value == RenameAnnotation {
+			rename = a
+			break
+		}
+	}
+
+	var clashes []string
+	for _, target := range r.targets {
+		if _, reserved := target.Keywords[name.Value]; !reserved {
+			continue
+		}
+		if rename != nil && renames(rename, target) {
+			continue
+		}
+		clashes = append(clashes, target.Name)
+	}
+
+	if len(clashes) > 0 {
+		context.ReportError(
+			ValidationError(
+				name,
+				"%s %q%s is a reserved word in %s: use a different name or @%s",
+				what,
+				name.Value,
+				parent,
+				strings.Join(clashes, ", "),
+				RenameAnnotation),
+		)
+	}
+}
+
+func renames(rename *ast.Annotation, target Target) bool {
+	for _, arg := range rename.Arguments {
+		if arg.Name.Value == target.Key {
+			return true
+		}
+		// A single unnamed argument applies to every target.
+		if arg.Name.Value == "value" && len(rename.Arguments) == 1 {
+			return true
+		}
+	}
+	return false
+}
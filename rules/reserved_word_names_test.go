@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestReservedWordNames(t *testing.T) {
+	t.Run("no targets", func(t *testing.T) {
+		testRule(t, rules.ReservedWordNames(), []ruleTest{
+			{
+				name: "reserved everywhere",
+				src: `namespace "test"
+type T { type: string class: string }`,
+			},
+		})
+	})
+
+	testRule(t, rules.ReservedWordNames(rules.TargetGo, rules.TargetTypeScript), []ruleTest{
+		{
+			name: "unreserved names",
+			src: `namespace "test"
+type Item { kind: string name: string }
+enum Color { RED = 0 }
+interface Store { get(id: string): Item }
+func find(name: string): Item`,
+		},
+		{
+			name: "reserved names",
+			src: `namespace "test"
+alias map = string
+type T { type: string class: string default: string }
+enum Kind { func = 0 }
+union select = T | string
+interface Store { import(package: string): T }
+func new(case: string): T`,
+			errors: []string{
+				`alias "map" is a reserved word in Go: use a different name or @rename`,
+				`func "new" is a reserved word in TypeScript: use a different name or @rename`,
+				`parameter "case" in "new" is a reserved word in Go, TypeScript: use a different name or @rename`,
+				`operation "import" in interface "Store" is a reserved word in Go, TypeScript: use a different name or @rename`,
+				`parameter "package" in "import" is a reserved word in Go, TypeScript: use a different name or @rename`,
+				`field "type" in type "T" is a reserved word in Go: use a different name or @rename`,
+				`field "class" in type "T" is a reserved word in TypeScript: use a different name or @rename`,
+				`field "default" in type "T" is a reserved word in Go, TypeScript: use a different name or @rename`,
+				`union "select" is a reserved word in Go: use a different name or @rename`,
+				`value "func" in enum "Kind" is a reserved word in Go: use a different name or @rename`,
+			},
+		},
+		{
+			name: "renamed",
+			src: `namespace "test"
+type T {
+  type: string @rename("kind")
+  class: string @rename(typescript: "klass")
+  default: string @rename(go: "Default")
+}`,
+			errors: []string{
+				`field "default" in type "T" is a reserved word in TypeScript: use a different name or @rename`,
+			},
+		},
+	})
+}
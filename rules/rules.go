@@ -17,6 +17,7 @@ limitations under the License.
 package rules
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/apexlang/apex-go/ast"
@@ -26,7 +27,11 @@ import (
 
 type ValidationRule func() ast.Visitor
 
-var Rules = []ValidationRule{
+// Rules are the validation rules of the default profile.
+var Rules = DefaultProfile.Rules()
+
+// coreRules apply regardless of the profile.
+var coreRules = []ValidationRule{
 	CamelCaseDirectiveNames,
 	KnownTypes,
 	NamespaceFirst,
@@ -39,29 +44,121 @@ var Rules = []ValidationRule{
 	UniqueOperationNames,
 	UniqueParameterNames,
 	UniqueTypeFieldNames,
+	UniqueUnionMembers,
 	ValidAnnotationArguments,
 	ValidAnnotationLocations,
 	ValidDirectiveLocation,
 	ValidDirectiveParameterTypes,
 	ValidDirectiveRequires,
 	ValidEnumValueIndexes,
+	ValidMapKeyTypes,
+	ValidStreamLocations,
+	ValidUnionMembers,
 }
 
 func Validate(
 	doc *ast.Document,
 	rules ...ValidationRule,
 ) []error {
-	context := ast.NewContext(doc)
+	return ValidateContext(context.Background(), doc, rules...)
+}
+
+// ValidateContext is like Validate but stops applying the rules once
+// ctx is done, checking it before each definition. The errors then
+// end with ctx.Err().
+func ValidateContext(
+	ctx context.Context,
+	doc *ast.Document,
+	rules ...ValidationRule,
+) []error {
+	c := ast.NewContext(doc)
 
 	ruleVisitors := make([]ast.Visitor, len(rules))
 	for i, rule := range rules {
 		ruleVisitors[i] = rule()
 	}
 
-	visitor := ast.NewMultiVisitor(ruleVisitors...)
+	visitor := &cancelVisitor{
+		Visitor: ast.NewMultiVisitor(ruleVisitors...),
+		ctx:     ctx,
+	}
+
+	doc.Accept(c, visitor)
+	errs := c.Errors()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// cancelVisitor forwards to the rule visitors until ctx is done and
+// then stops the walk.
+type cancelVisitor struct {
+	ast.Visitor
+	ctx context.Context
+}
+
+// check returns true if the walk may go on, stopping it otherwise.
+func (v *cancelVisitor) check(context ast.Context) bool {
+	if v.ctx.Err() != nil {
+		context.Stop()
+		return false
+	}
+	return true
+}
+
+func (v *cancelVisitor) VisitNamespace(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitNamespace(context)
+	}
+}
+
+func (v *cancelVisitor) VisitImport(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitImport(context)
+	}
+}
+
+func (v *cancelVisitor) VisitDirectiveBefore(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitDirectiveBefore(context)
+	}
+}
+
+func (v *cancelVisitor) VisitAliasBefore(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitAliasBefore(context)
+	}
+}
+
+func (v *cancelVisitor) VisitFunctionBefore(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitFunctionBefore(context)
+	}
+}
+
+func (v *cancelVisitor) VisitInterfaceBefore(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitInterfaceBefore(context)
+	}
+}
+
+func (v *cancelVisitor) VisitTypeBefore(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitTypeBefore(context)
+	}
+}
+
+func (v *cancelVisitor) VisitUnion(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitUnion(context)
+	}
+}
 
-	doc.Accept(context, visitor)
-	return context.Errors()
+func (v *cancelVisitor) VisitEnumBefore(context ast.Context) {
+	if v.check(context) {
+		v.Visitor.VisitEnumBefore(context)
+	}
 }
 
 func ValidationError(node ast.Node, format string, a ...interface{}) *errors.Error {
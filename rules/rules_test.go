@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+// ruleTest is a spec and the errors a rule reports for it, without the
+// "Validation Error: " prefix.
+type ruleTest struct {
+	name string
+	src  string
+	// edit, if set, changes the parsed document, for example to build
+	// types the parser does not accept.
+	edit   func(doc *ast.Document)
+	errors []string
+	// fixed, if set, is src after applying the edits of the errors.
+	fixed string
+}
+
+// testRule checks the errors reported by rule for each spec in tests.
+func testRule(t *testing.T, rule rules.ValidationRule, tests []ruleTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.src})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.edit != nil {
+				tt.edit(doc)
+			}
+			var messages []string
+			var edits []source.TextEdit
+			for _, err := range rules.Validate(doc, rule) {
+				messages = append(messages, strings.TrimPrefix(err.Error(), "Validation Error: "))
+				if e, ok := err.(*errors.Error); ok {
+					edits = append(edits, e.Edits...)
+				}
+			}
+			if !reflect.DeepEqual(messages, tt.errors) {
+				t.Errorf("got errors\n%q\nwant\n%q", messages, tt.errors)
+			}
+			if tt.fixed == "" {
+				return
+			}
+			fixed, err := source.ApplyEdits([]byte(tt.src), edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(fixed) != tt.fixed {
+				t.Errorf("got fixed spec\n%s\nwant\n%s", fixed, tt.fixed)
+			}
+		})
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `type A { a: string }
+namespace "test"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs := rules.ValidateContext(ctx, doc, rules.NamespaceFirst)
+	if len(errs) != 1 || !stderrors.Is(errs[0], context.Canceled) {
+		t.Errorf("ValidateContext() with a canceled context = %v, want only %v", errs, context.Canceled)
+	}
+}
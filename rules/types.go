@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// typeString returns the type as it would be written in a spec.
+func typeString(t ast.Type) string {
+	var sb strings.Builder
+	writeType(&sb, t)
+	return sb.String()
+}
+
+func writeType(sb *strings.Builder, t ast.Type) {
+	switch v := t.(type) {
+	case *ast.Named:
+		sb.WriteString(v.Name.Value)
+	case *ast.ListType:
+		sb.WriteString("[")
+		writeType(sb, v.Type)
+		sb.WriteString("]")
+	case *ast.MapType:
+		sb.WriteString("{")
+		writeType(sb, v.KeyType)
+		sb.WriteString(": ")
+		writeType(sb, v.ValueType)
+		sb.WriteString("}")
+	case *ast.Optional:
+		writeType(sb, v.Type)
+		sb.WriteString("?")
+	case *ast.Stream:
+		sb.WriteString("stream ")
+		writeType(sb, v.Type)
+	}
+}
+
+// resolveType follows aliases until a type that is not
+// an alias is found. Alias cycles resolve to nil.
+func resolveType(context ast.Context, t ast.Type) ast.Type {
+	seen := map[string]struct{}{}
+	for {
+		named, ok := t.(*ast.Named)
+		if !ok {
+			return t
+		}
+		alias, ok := context.Named[named.Name.Value].(*ast.AliasDefinition)
+		if !ok {
+			return t
+		}
+		if _, cycle := seen[alias.Name.Value]; cycle {
+			return nil
+		}
+		seen[alias.Name.Value] = struct{}{}
+		t = alias.Type
+	}
+}
+
+// resolveDefinition returns the type, union or enum definition
+// that t refers to directly or through aliases.
+func resolveDefinition(context ast.Context, t ast.Type) ast.Definition {
+	if named, ok := resolveType(context, t).(*ast.Named); ok {
+		return context.Named[named.Name.Value]
+	}
+	return nil
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package rules
 
 import (
+	"strconv"
+
 	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
 )
 
 func UniqueEnumValueIndexes() ast.Visitor {
@@ -28,20 +31,36 @@ type uniqueEnumValueIndexes struct {
 	ast.BaseVisitor
 	parentName string
 	values     map[int]struct{}
+	// next is the index suggested for the next duplicate.
+	next int
 }
 
 func (r *uniqueEnumValueIndexes) VisitEnumBefore(context ast.Context) {
 	r.parentName = context.Enum.Name.Value
 	r.values = map[int]struct{}{}
+	r.next = 0
+	for _, value := range context.Enum.Values {
+		if value.Index != nil && value.Index.Value >= r.next {
+			r.next = value.Index.Value + 1
+		}
+	}
 }
 
 func (r *uniqueEnumValueIndexes) VisitEnumValue(context ast.Context) {
 	enumValue := context.EnumValue
 	value := enumValue.Index.Value
 	if _, duplicate := r.values[value]; duplicate {
-		context.ReportError(
-			ValidationError(enumValue.Index, "duplicate index %d in enum %q", value, r.parentName),
-		)
+		err := ValidationError(enumValue.Index, "duplicate index %d in enum %q", value, r.parentName)
+		loc := enumValue.Index.Loc
+		if text, ok := sourceText(loc, documentSource(context)); ok && text == strconv.Itoa(value) {
+			err.Edits = []source.TextEdit{{
+				Start:   loc.Start,
+				End:     loc.End,
+				NewText: strconv.Itoa(r.next),
+			}}
+			r.next++
+		}
+		context.ReportError(err)
 		return
 	}
 
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestUniqueEnumValueIndexes(t *testing.T) {
+	const duplicates = `namespace "test"
+enum E { A = 0 B = 0 C = 4 D = 4 }
+`
+	index := func(doc *ast.Document, i int) *ast.IntValue {
+		return doc.Definitions[1].(*ast.EnumDefinition).Values[i].Index
+	}
+	testRule(t, rules.UniqueEnumValueIndexes, []ruleTest{
+		{
+			name: "unique indexes",
+			src: `namespace "test"
+enum E { A = 0 B = 1 }
+enum F { A = 0 B = 1 }`,
+		},
+		{
+			name: "duplicate indexes",
+			src:  duplicates,
+			errors: []string{
+				`duplicate index 0 in enum "E"`,
+				`duplicate index 4 in enum "E"`,
+			},
+			fixed: `namespace "test"
+enum E { A = 0 B = 5 C = 4 D = 6 }
+`,
+		},
+		{
+			name: "index from another source",
+			src:  duplicates,
+			edit: func(doc *ast.Document) {
+				for _, i := range []int{1, 3} {
+					v := index(doc, i)
+					loc := *v.Loc
+					loc.Source = source.NewSource("other.apex", loc.Source.Body)
+					v.Loc = &loc
+				}
+			},
+			errors: []string{
+				`duplicate index 0 in enum "E"`,
+				`duplicate index 4 in enum "E"`,
+			},
+			fixed: duplicates,
+		},
+		{
+			name: "index not in the source",
+			src:  duplicates,
+			edit: func(doc *ast.Document) {
+				index(doc, 2).Value = 0
+				index(doc, 3).Value = 0
+			},
+			errors: []string{
+				`duplicate index 0 in enum "E"`,
+				`duplicate index 0 in enum "E"`,
+				`duplicate index 0 in enum "E"`,
+			},
+			fixed: `namespace "test"
+enum E { A = 0 B = 1 C = 4 D = 4 }
+`,
+		},
+	})
+}
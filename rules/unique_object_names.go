@@ -50,7 +50,7 @@ func (r *uniqueObjectNames) VisitAlias(context ast.Context) {
 func (r *uniqueObjectNames) check(context ast.Context, name *ast.Name, typeName string) {
 	if _, duplicate := r.names[name.Value]; duplicate {
 		context.ReportError(
-			ValidationError(name, "duplicate %s %q", typeName, name),
+			ValidationError(name, "duplicate %s %q", typeName, name.Value),
 		)
 		return
 	}
@@ -0,0 +1,40 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+)
+
+func UniqueUnionMembers() ast.Visitor { return &uniqueUnionMembers{} }
+
+type uniqueUnionMembers struct{ ast.BaseVisitor }
+
+func (r *uniqueUnionMembers) VisitUnion(context ast.Context) {
+	union := context.Union
+	members := make(map[string]struct{}, len(union.Types))
+	for _, ut := range union.Types {
+		name := typeString(ut)
+		if _, duplicate := members[name]; duplicate {
+			context.ReportError(
+				ValidationError(ut, "duplicate member %q in union %q", name, union.Name.Value),
+			)
+			continue
+		}
+		members[name] = struct{}{}
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestUniqueUnionMembers(t *testing.T) {
+	testRule(t, rules.UniqueUnionMembers, []ruleTest{
+		{
+			name: "distinct members",
+			src: `namespace "test"
+type A { a: string }
+type B { b: string }
+union U = A | B | [A] | {string: A}`,
+		},
+		{
+			name: "duplicate members",
+			src: `namespace "test"
+type A { a: string }
+union U = A | string | A | [string] | [string]`,
+			errors: []string{
+				`duplicate member "A" in union "U"`,
+				`duplicate member "[string]" in union "U"`,
+			},
+		},
+	})
+}
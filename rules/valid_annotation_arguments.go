@@ -21,6 +21,7 @@ import (
 
 	"github.com/apexlang/apex-go/ast"
 	"github.com/apexlang/apex-go/kinds"
+	"github.com/apexlang/apex-go/suggest"
 )
 
 func ValidAnnotationArguments() ast.Visitor { return &validAnnotationArguments{} }
@@ -93,12 +94,17 @@ func (r *validAnnotationArguments) VisitAnnotation(context ast.Context) {
 		r.check(context, param.Type, arg.Value, a)
 	}
 
+	paramNames := make([]string, len(dir.Parameters))
+	for i, param := range dir.Parameters {
+		paramNames[i] = param.Name.Value
+	}
 	for _, arg := range args {
-		context.ReportError(
-			ValidationError(
-				arg,
-				"unknown parameter %q in directive %q", arg.Name.Value, dir.Name.Value),
-		)
+		suggestions := suggest.Names(arg.Name.Value, paramNames)
+		err := ValidationError(
+			arg,
+			"unknown parameter %q in directive %q%s", arg.Name.Value, dir.Name.Value, suggest.Message(suggestions))
+		err.Suggestions = suggestions
+		context.ReportError(err)
 	}
 }
 
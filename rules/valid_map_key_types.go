@@ -0,0 +1,177 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+func ValidMapKeyTypes() ast.Visitor { return &validMapKeyTypes{} }
+
+type validMapKeyTypes struct{ ast.BaseVisitor }
+
+// mapKeyBuiltInTypeNames are the built-in types that every
+// target language can hash and use as a map key.
+var mapKeyBuiltInTypeNames = map[string]struct{}{
+	"string": {},
+	"bool":   {},
+	"i8":     {},
+	"u8":     {},
+	"i16":    {},
+	"u16":    {},
+	"i32":    {},
+	"u32":    {},
+	"i64":    {},
+	"u64":    {},
+}
+
+func (r *validMapKeyTypes) VisitAlias(context ast.Context) {
+	alias := context.Alias
+	r.check(context, `alias`, alias.Name.Value, alias.Type)
+}
+
+func (r *validMapKeyTypes) VisitOperation(context ast.Context) {
+	oper := context.Operation
+	r.check(context, `return`, oper.Name.Value, oper.Type)
+}
+
+func (r *validMapKeyTypes) VisitFunction(context ast.Context) {
+	function := context.Function
+	r.check(context, `return`, function.Name.Value, function.Type)
+}
+
+func (r *validMapKeyTypes) VisitParameter(context ast.Context) {
+	oper := context.Operation
+	param := context.Parameter
+	r.check(
+		context,
+		fmt.Sprintf(`parameter %q`, param.Name.Value),
+		oper.Name.Value,
+		param.Type,
+	)
+}
+
+func (r *validMapKeyTypes) VisitTypeField(context ast.Context) {
+	t := context.Type
+	field := context.Field
+	r.check(
+		context,
+		fmt.Sprintf(`field %q`, field.Name.Value),
+		t.Name.Value,
+		field.Type,
+	)
+}
+
+func (r *validMapKeyTypes) VisitUnion(context ast.Context) {
+	union := context.Union
+	for _, ut := range union.Types {
+		r.check(
+			context,
+			fmt.Sprintf(`union %q`, union.Name.Value),
+			union.Name.Value,
+			ut,
+		)
+	}
+}
+
+func (r *validMapKeyTypes) VisitDirectiveParameter(context ast.Context) {
+	directive := context.Directive
+	param := context.Parameter
+	r.check(
+		context,
+		fmt.Sprintf(`parameter %q`, param.Name.Value),
+		directive.Name.Value,
+		param.Type,
+	)
+}
+
+func (r *validMapKeyTypes) check(
+	context ast.Context,
+	forName string,
+	parentName string,
+	t ast.Type,
+) {
+	switch v := t.(type) {
+	case *ast.MapType:
+		if !r.validKey(context, v.KeyType, map[string]struct{}{}) {
+			context.ReportError(
+				ValidationError(
+					v.KeyType,
+					"invalid map key type for %s in %q: expected a string, bool, integer, enum or string alias",
+					forName,
+					parentName,
+				),
+			)
+		}
+		r.check(context, forName, parentName, v.KeyType)
+		r.check(context, forName, parentName, v.ValueType)
+
+	case *ast.Optional:
+		r.check(context, forName, parentName, v.Type)
+
+	case *ast.ListType:
+		r.check(context, forName, parentName, v.Type)
+
+	case *ast.Stream:
+		r.check(context, forName, parentName, v.Type)
+	}
+}
+
+// validKey returns true if t can be used as a map key. Aliases
+// are followed until a built-in type is found and the seen
+// names guard against alias cycles.
+func (r *validMapKeyTypes) validKey(
+	context ast.Context,
+	t ast.Type,
+	seen map[string]struct{},
+) bool {
+	named, ok := t.(*ast.Named)
+	if !ok {
+		return false
+	}
+	name := named.Name.Value
+	if _, ok := mapKeyBuiltInTypeNames[name]; ok {
+		return true
+	}
+	if _, ok := builtInTypeNames[name]; ok {
+		return false
+	}
+
+	switch def := context.Named[name].(type) {
+	case nil:
+		// Error reported by KnownTypes
+		return true
+	case *ast.EnumDefinition:
+		return true
+	case *ast.AliasDefinition:
+		if _, cycle := seen[name]; cycle {
+			return false
+		}
+		seen[name] = struct{}{}
+		if aliased, ok := def.Type.(*ast.Named); ok &&
+			aliased.Name.Value != "string" {
+			if _, builtIn := builtInTypeNames[aliased.Name.Value]; builtIn {
+				return false
+			}
+		}
+		return r.validKey(context, def.Type, seen)
+	}
+
+	return false
+}
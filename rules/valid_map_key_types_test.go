@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestValidMapKeyTypes(t *testing.T) {
+	testRule(t, rules.ValidMapKeyTypes, []ruleTest{
+		{
+			name: "valid keys",
+			src: `namespace "test"
+alias Key = string
+alias Ref = Key
+enum Color { RED = 0 }
+type T {
+  a: {string: i64}
+  b: {u32: string}
+  c: {Color: bool}
+  d: {Ref: [string]}
+  e: {string: {i8: string}}
+}`,
+		},
+		{
+			name: "invalid keys",
+			src: `namespace "test"
+alias Number = i32
+alias Loop = Loop
+type Point { x: f64 }
+type T {
+  a: {f64: string}
+  b: {Point: string}
+  c: {Number: string}
+  d: {Loop: string}
+  e: [{bytes: string}?]
+}
+func get(m: {[string]: string}): {datetime: string}`,
+			errors: []string{
+				`invalid map key type for return in "get": expected a string, bool, integer, enum or string alias`,
+				`invalid map key type for parameter "m" in "get": expected a string, bool, integer, enum or string alias`,
+				`invalid map key type for field "a" in "T": expected a string, bool, integer, enum or string alias`,
+				`invalid map key type for field "b" in "T": expected a string, bool, integer, enum or string alias`,
+				`invalid map key type for field "c" in "T": expected a string, bool, integer, enum or string alias`,
+				`invalid map key type for field "d" in "T": expected a string, bool, integer, enum or string alias`,
+				`invalid map key type for field "e" in "T": expected a string, bool, integer, enum or string alias`,
+			},
+		},
+	})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+func ValidStreamLocations() ast.Visitor { return &validStreamLocations{} }
+
+type validStreamLocations struct{ ast.BaseVisitor }
+
+func (r *validStreamLocations) VisitAlias(context ast.Context) {
+	alias := context.Alias
+	r.check(context, `alias`, alias.Name.Value, alias.Type, false)
+}
+
+func (r *validStreamLocations) VisitOperation(context ast.Context) {
+	oper := context.Operation
+	r.check(context, `return`, oper.Name.Value, oper.Type, true)
+}
+
+func (r *validStreamLocations) VisitFunction(context ast.Context) {
+	function := context.Function
+	r.check(context, `return`, function.Name.Value, function.Type, true)
+}
+
+func (r *validStreamLocations) VisitParameter(context ast.Context) {
+	oper := context.Operation
+	param := context.Parameter
+	r.check(
+		context,
+		fmt.Sprintf(`parameter %q`, param.Name.Value),
+		oper.Name.Value,
+		param.Type,
+		true,
+	)
+}
+
+func (r *validStreamLocations) VisitTypeField(context ast.Context) {
+	t := context.Type
+	field := context.Field
+	r.check(
+		context,
+		fmt.Sprintf(`field %q`, field.Name.Value),
+		t.Name.Value,
+		field.Type,
+		false,
+	)
+}
+
+func (r *validStreamLocations) VisitUnion(context ast.Context) {
+	union := context.Union
+	for _, ut := range union.Types {
+		r.check(
+			context,
+			fmt.Sprintf(`union %q`, union.Name.Value),
+			union.Name.Value,
+			ut,
+			false,
+		)
+	}
+}
+
+func (r *validStreamLocations) VisitDirectiveParameter(context ast.Context) {
+	directive := context.Directive
+	param := context.Parameter
+	r.check(
+		context,
+		fmt.Sprintf(`parameter %q`, param.Name.Value),
+		directive.Name.Value,
+		param.Type,
+		false,
+	)
+}
+
+// check reports any stream found in t. Streams are only
+// allowed as the outermost type of operation returns and
+// parameters, which is signaled by allowed.
+func (r *validStreamLocations) check(
+	context ast.Context,
+	forName string,
+	parentName string,
+	t ast.Type,
+	allowed bool,
+) {
+	switch v := t.(type) {
+	case *ast.Stream:
+		if !allowed {
+			context.ReportError(
+				ValidationError(
+					v,
+					"invalid stream for %s in %q: streams are only allowed as operation return and parameter types",
+					forName,
+					parentName,
+				),
+			)
+		}
+		r.check(context, forName, parentName, v.Type, false)
+
+	case *ast.Optional:
+		r.check(context, forName, parentName, v.Type, false)
+
+	case *ast.MapType:
+		r.check(context, forName, parentName, v.KeyType, false)
+		r.check(context, forName, parentName, v.ValueType, false)
+
+	case *ast.ListType:
+		r.check(context, forName, parentName, v.Type, false)
+	}
+}
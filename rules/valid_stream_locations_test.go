@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestValidStreamLocations(t *testing.T) {
+	testRule(t, rules.ValidStreamLocations, []ruleTest{
+		{
+			name: "operation returns and parameters",
+			src: `namespace "test"
+interface Feed {
+  watch(from: stream string): stream string
+  upload[chunks: stream bytes]: u64
+}
+func events(): stream i64`,
+		},
+		{
+			name: "directive parameter",
+			src: `namespace "test"
+directive @feed(items: stream string) on TYPE`,
+			errors: []string{
+				`invalid stream for parameter "items" in "feed": streams are only allowed as operation return and parameter types`,
+			},
+		},
+		{
+			// The parser only reads streams in operations and
+			// directive parameters, so the other locations are built.
+			name: "built locations",
+			src: `namespace "test"
+alias Events = string
+type Page { items: string }
+union Item = Page | string
+interface Feed {
+  watch(from: string): string
+}`,
+			edit: func(doc *ast.Document) {
+				stream := ast.StreamOf(ast.TypeName("string"))
+				defs := doc.Definitions
+				defs[1].(*ast.AliasDefinition).Type = stream
+				defs[2].(*ast.TypeDefinition).Fields[0].Type = ast.ListOf(stream)
+				defs[3].(*ast.UnionDefinition).Types[1] = stream
+				watch := defs[4].(*ast.InterfaceDefinition).Operations[0]
+				watch.Parameters[0].Type = ast.OptionalOf(stream)
+				watch.Type = ast.StreamOf(ast.MapOf(ast.TypeName("string"), stream))
+			},
+			errors: []string{
+				`invalid stream for alias in "Events": streams are only allowed as operation return and parameter types`,
+				`invalid stream for return in "watch": streams are only allowed as operation return and parameter types`,
+				`invalid stream for parameter "from" in "watch": streams are only allowed as operation return and parameter types`,
+				`invalid stream for field "items" in "Page": streams are only allowed as operation return and parameter types`,
+				`invalid stream for union "Item" in "Item": streams are only allowed as operation return and parameter types`,
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+)
+
+func ValidUnionMembers() ast.Visitor { return &validUnionMembers{} }
+
+type validUnionMembers struct{ ast.BaseVisitor }
+
+func (r *validUnionMembers) VisitUnion(context ast.Context) {
+	union := context.Union
+	for _, ut := range union.Types {
+		if _, ok := ut.(*ast.Optional); ok {
+			context.ReportError(
+				ValidationError(
+					ut,
+					"invalid member %q in union %q: members cannot be optional",
+					typeString(ut),
+					union.Name.Value),
+			)
+			continue
+		}
+
+		if _, ok := resolveDefinition(context, ut).(*ast.UnionDefinition); ok {
+			context.ReportError(
+				ValidationError(
+					ut,
+					"invalid member %q in union %q: unions cannot be nested",
+					typeString(ut),
+					union.Name.Value),
+			)
+		}
+	}
+}
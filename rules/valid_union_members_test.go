@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestValidUnionMembers(t *testing.T) {
+	testRule(t, rules.ValidUnionMembers, []ruleTest{
+		{
+			name: "valid members",
+			src: `namespace "test"
+type A { a: string }
+alias Names = [string]
+union U = A | Names | string`,
+		},
+		{
+			name: "optional and nested members",
+			src: `namespace "test"
+type A { a: string }
+union Inner = A | string
+alias Alias = Inner
+union U = A? | Inner | Alias`,
+			errors: []string{
+				`invalid member "A?" in union "U": members cannot be optional`,
+				`invalid member "Inner" in union "U": unions cannot be nested`,
+				`invalid member "Alias" in union "U": unions cannot be nested`,
+			},
+		},
+	})
+}
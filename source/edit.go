@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TextEdit replaces the bytes from Start up to End of a source body
+// with NewText. An empty range inserts and an empty NewText deletes.
+type TextEdit struct {
+	Start   uint   `json:"start"`
+	End     uint   `json:"end"`
+	NewText string `json:"newText"`
+}
+
+// ApplyEdits returns a copy of body with all edits applied. The edits
+// may be given in any order but must not overlap.
+func ApplyEdits(body []byte, edits []TextEdit) ([]byte, error) {
+	sorted := make([]TextEdit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var out []byte
+	var pos uint
+	for _, edit := range sorted {
+		if edit.Start > edit.End || edit.End > uint(len(body)) {
+			return nil, fmt.Errorf("edit %d:%d is out of range", edit.Start, edit.End)
+		}
+		if edit.Start < pos {
+			return nil, fmt.Errorf("edit %d:%d overlaps another edit", edit.Start, edit.End)
+		}
+		out = append(out, body[pos:edit.Start]...)
+		out = append(out, edit.NewText...)
+		pos = edit.End
+	}
+	out = append(out, body[pos:]...)
+	return out, nil
+}
+
+// Overlaps returns true if any of the edits in a touch the range of
+// an edit in b. Insertions at the same position also overlap since
+// their order would be ambiguous.
+func Overlaps(a, b []TextEdit) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Start < y.End && y.Start < x.End ||
+				x.Start == y.Start {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyEdits(t *testing.T) {
+	const body = "type a { b: c }"
+	tests := []struct {
+		name  string
+		edits []TextEdit
+		want  string
+		err   string
+	}{
+		{"no edits", nil, body, ""},
+		{"replace", []TextEdit{{5, 6, "A"}}, "type A { b: c }", ""},
+		{"insert", []TextEdit{{0, 0, "# "}}, "# type a { b: c }", ""},
+		{"delete", []TextEdit{{6, 15, ""}}, "type a", ""},
+		{"append", []TextEdit{{15, 15, "\n"}}, body + "\n", ""},
+		{"any order", []TextEdit{{12, 13, "C"}, {5, 6, "A"}}, "type A { b: C }", ""},
+		{"adjacent", []TextEdit{{5, 6, "A"}, {6, 6, "B"}}, "type AB { b: c }", ""},
+		{"overlapping", []TextEdit{{5, 8, "A"}, {7, 9, "B"}}, "", "overlaps"},
+		{"past the end", []TextEdit{{10, 16, ""}}, "", "out of range"},
+		{"reversed", []TextEdit{{6, 5, ""}}, "", "out of range"},
+	}
+	for _, tt := range tests {
+		got, err := ApplyEdits([]byte(body), tt.edits)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: ApplyEdits() error = %v, want %q", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ApplyEdits() error = %v", tt.name, err)
+		} else if string(got) != tt.want {
+			t.Errorf("%s: ApplyEdits() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEditsKeepsBody(t *testing.T) {
+	body := []byte("type a")
+	if _, err := ApplyEdits(body, []TextEdit{{5, 6, "A"}}); err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "type a" {
+		t.Errorf("body changed to %q", body)
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		a, b TextEdit
+		want bool
+	}{
+		{TextEdit{0, 5, ""}, TextEdit{5, 8, ""}, false},
+		{TextEdit{0, 5, ""}, TextEdit{4, 8, ""}, true},
+		{TextEdit{2, 3, ""}, TextEdit{0, 8, ""}, true},
+		{TextEdit{5, 5, "x"}, TextEdit{5, 5, "y"}, true},
+		{TextEdit{5, 5, "x"}, TextEdit{4, 6, ""}, true},
+		{TextEdit{5, 5, "x"}, TextEdit{5, 6, ""}, true},
+		{TextEdit{6, 6, "x"}, TextEdit{5, 6, ""}, false},
+	}
+	for _, tt := range tests {
+		a, b := []TextEdit{tt.a}, []TextEdit{tt.b}
+		if got := Overlaps(a, b); got != tt.want {
+			t.Errorf("Overlaps(%v, %v) = %v, want %v", a, b, got, tt.want)
+		}
+		if got := Overlaps(b, a); got != tt.want {
+			t.Errorf("Overlaps(%v, %v) = %v, want %v", b, a, got, tt.want)
+		}
+	}
+	if Overlaps(nil, []TextEdit{{0, 1, ""}}) {
+		t.Error("Overlaps(nil, ...) = true")
+	}
+}
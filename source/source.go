@@ -16,14 +16,182 @@ limitations under the License.
 
 package source
 
+import (
+	"sort"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
 type Source struct {
 	Body []byte `json:"body,omitempty"`
 	Name string `json:"name,omitempty"`
+
+	// lines holds the offsets at which the lines of Body start, built on
+	// first use. Body must not change once a position is looked up.
+	lines atomic.Value
 }
 
+// Unit is the unit in which columns are counted.
+type Unit int
+
+const (
+	// Bytes counts columns in bytes of the UTF-8 body.
+	Bytes Unit = iota
+	// Runes counts columns in Unicode code points.
+	Runes
+	// UTF16 counts columns in UTF-16 code units, as the Language Server
+	// Protocol and SARIF do.
+	UTF16
+)
+
 func NewSource(name string, body []byte) *Source {
 	return &Source{
 		Name: name,
 		Body: body,
 	}
 }
+
+// LineStarts returns the byte offsets at which the lines of the body
+// start, beginning with 0. Lines end at "\r\n", "\n" or "\r".
+func (s *Source) LineStarts() []uint {
+	if lines, ok := s.lines.Load().([]uint); ok {
+		return lines
+	}
+	lines := lineStarts(s.Body)
+	s.lines.Store(lines)
+	return lines
+}
+
+// LineCount returns the number of lines in the body. An empty body and
+// a body ending with a line terminator have an empty last line.
+func (s *Source) LineCount() uint {
+	return uint(len(s.LineStarts()))
+}
+
+// Line returns the text of the line numbered line, starting at 1,
+// without its line terminator. Lines out of range are empty.
+func (s *Source) Line(line uint) []byte {
+	start, end, ok := s.lineRange(line)
+	if !ok {
+		return nil
+	}
+	return s.Body[start:end]
+}
+
+// Location returns the line and column, both starting at 1, of the byte
+// at offset with the column counted in unit. Offsets past the end of the
+// body continue its last line, one column per byte.
+func (s *Source) Location(offset uint, unit Unit) (line, column uint) {
+	var past uint
+	if offset > uint(len(s.Body)) {
+		past = offset - uint(len(s.Body))
+		offset = uint(len(s.Body))
+	}
+	lines := s.LineStarts()
+	// line is the number of lines starting at or before offset.
+	line = uint(sort.Search(len(lines), func(i int) bool {
+		return lines[i] > offset
+	}))
+	start := lines[line-1]
+	return line, width(s.Body[start:offset], unit) + past + 1
+}
+
+// Offset returns the byte offset of the line and column, both starting
+// at 1, with the column counted in unit. As in the Language Server
+// Protocol, columns past the end of a line refer to the end of the line
+// and lines past the end of the body refer to the end of the body. A
+// column inside a UTF-16 surrogate pair refers to the start of its rune.
+func (s *Source) Offset(line, column uint, unit Unit) uint {
+	if line < 1 {
+		return 0
+	}
+	start, end, ok := s.lineRange(line)
+	if !ok {
+		return uint(len(s.Body))
+	}
+	var target uint
+	if column > 1 {
+		target = column - 1
+	}
+	text := s.Body[start:end]
+	var n, i uint
+	for n < target && i < uint(len(text)) {
+		r, size := utf8.DecodeRune(text[i:])
+		w := runeWidth(r, size, unit)
+		if n+w > target {
+			break
+		}
+		n += w
+		i += uint(size)
+	}
+	return start + i
+}
+
+// lineRange returns the offsets of the first byte and of the terminator
+// of the line numbered line.
+func (s *Source) lineRange(line uint) (start, end uint, ok bool) {
+	lines := s.LineStarts()
+	if line < 1 || line > uint(len(lines)) {
+		return 0, 0, false
+	}
+	start = lines[line-1]
+	if line == uint(len(lines)) {
+		// The last line has no terminator.
+		return start, uint(len(s.Body)), true
+	}
+	end = lines[line]
+	if s.Body[end-1] == '\n' {
+		end--
+	}
+	if end > start && s.Body[end-1] == '\r' {
+		end--
+	}
+	return start, end, true
+}
+
+// width returns the length of text counted in unit. Invalid UTF-8 counts
+// as one unit per byte.
+func width(text []byte, unit Unit) uint {
+	switch unit {
+	case Runes:
+		return uint(utf8.RuneCount(text))
+	case UTF16:
+		var n uint
+		for i := 0; i < len(text); {
+			r, size := utf8.DecodeRune(text[i:])
+			n += runeWidth(r, size, unit)
+			i += size
+		}
+		return n
+	}
+	return uint(len(text))
+}
+
+func runeWidth(r rune, size int, unit Unit) uint {
+	switch unit {
+	case Runes:
+		return 1
+	case UTF16:
+		if r > 0xFFFF {
+			return 2
+		}
+		return 1
+	}
+	return uint(size)
+}
+
+func lineStarts(body []byte) []uint {
+	lines := []uint{0}
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\r':
+			if i+1 < len(body) && body[i+1] == '\n' {
+				i++
+			}
+			lines = append(lines, uint(i+1))
+		case '\n':
+			lines = append(lines, uint(i+1))
+		}
+	}
+	return lines
+}
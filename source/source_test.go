@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+)
+
+func TestLocationAndOffset(t *testing.T) {
+	// "é" is 2 bytes and 1 UTF-16 unit, "𝄞" is 4 bytes and 2 UTF-16 units.
+	s := NewSource("test", []byte("ab\r\né𝄞x\rlast\n"))
+	tests := []struct {
+		offset       uint
+		unit         Unit
+		line, column uint
+	}{
+		{0, Bytes, 1, 1},
+		{2, Bytes, 1, 3},
+		{4, Bytes, 2, 1},
+		{10, Bytes, 2, 7},
+		{10, Runes, 2, 3},
+		{10, UTF16, 2, 4},
+		{11, UTF16, 2, 5},
+		{12, Runes, 3, 1},
+		{17, Bytes, 4, 1},
+	}
+	for _, tt := range tests {
+		line, column := s.Location(tt.offset, tt.unit)
+		if line != tt.line || column != tt.column {
+			t.Errorf("Location(%d, %d) = %d:%d, want %d:%d", tt.offset, tt.unit, line, column, tt.line, tt.column)
+		}
+		if offset := s.Offset(tt.line, tt.column, tt.unit); offset != tt.offset {
+			t.Errorf("Offset(%d, %d, %d) = %d, want %d", tt.line, tt.column, tt.unit, offset, tt.offset)
+		}
+	}
+
+	if got := string(s.Line(2)); got != "é𝄞x" {
+		t.Errorf("Line(2) = %q", got)
+	}
+	if n := s.LineCount(); n != 4 {
+		t.Errorf("LineCount() = %d, want 4", n)
+	}
+	// Columns past the end of a line and lines past the end of the body
+	// are clamped, as in the Language Server Protocol.
+	if offset := s.Offset(1, 10, Bytes); offset != 2 {
+		t.Errorf("Offset(1, 10) = %d, want 2", offset)
+	}
+	if offset := s.Offset(9, 1, Bytes); offset != 17 {
+		t.Errorf("Offset(9, 1) = %d, want 17", offset)
+	}
+}
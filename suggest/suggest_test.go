@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package suggest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apexlang/apex-go/suggest"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		want       []string
+	}{
+		{"strng", []string{"string", "bool", "i32"}, []string{"string"}},
+		{"Usr", []string{"User", "Users", "Group"}, []string{"User"}},
+		{"user", []string{"User", "Users"}, []string{"User", "Users"}},
+		{"Item", []string{"Item", "Items"}, []string{"Items"}},
+		{"Ordr", []string{"Order", "Order", "Odor", "Orders"}, []string{"Order"}},
+		{"Ordr", []string{"Ordrs", "Order", "Orde", "Ord", "Odr"}, []string{"Odr", "Ord", "Orde"}},
+		{"Color", []string{"Shape", "", "Size"}, nil},
+		{"a", []string{"b", "ab", "abc"}, []string{"ab", "b"}},
+		{"x", nil, nil},
+	}
+	for _, tt := range tests {
+		if got := suggest.Names(tt.name, tt.candidates); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Names(%q, %q) = %q, want %q", tt.name, tt.candidates, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"string", "strng", 1},
+		{"日本", "日本語", 1},
+	}
+	for _, tt := range tests {
+		if got := suggest.Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		suggestions []string
+		want        string
+	}{
+		{nil, ""},
+		{[]string{"User"}, `; did you mean "User"?`},
+		{[]string{"User", "Users"}, `; did you mean "User" or "Users"?`},
+	}
+	for _, tt := range tests {
+		if got := suggest.Message(tt.suggestions); got != tt.want {
+			t.Errorf("Message(%q) = %q, want %q", tt.suggestions, got, tt.want)
+		}
+	}
+}
@@ -24,6 +24,9 @@ func NewResolver(binding ...string) *ResolverImpl {
 }
 
 func (h *ResolverImpl) Resolve(ctx context.Context, location string, from string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	inputArgs := model.ResolverResolveArgs{
 		Location: location,
 		From:     from,
@@ -45,16 +48,59 @@ func (h *ResolverImpl) Resolve(ctx context.Context, location string, from string
 	return decoder.ReadString()
 }
 
-func RegisterParser(svc model.Parser) {
-	wapc.RegisterFunction("apexlang.v1.Parser/parse", parserParseWrapper(svc))
+// Option configures the functions registered by RegisterParser.
+type Option func(*options)
+
+type options struct {
+	newContext func(operation string) context.Context
+}
+
+// WithContext sets the function that creates the context of each call,
+// for example to bound it with a deadline. The default is
+// context.Background. The context passed to the service also carries
+// the operation name.
+func WithContext(newContext func(operation string) context.Context) Option {
+	return func(o *options) {
+		o.newContext = newContext
+	}
+}
+
+type operationKey struct{}
+
+// Operation returns the name of the waPC operation being called, such
+// as "apexlang.v1.Parser/parse".
+func Operation(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
+
+func (o *options) context(operation string) context.Context {
+	ctx := context.Background()
+	if o.newContext != nil {
+		ctx = o.newContext(operation)
+	}
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+func RegisterParser(svc model.ParserService, opts ...Option) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	wapc.RegisterFunction("apexlang.v1.Parser/parse", parserParseWrapper(svc, &o))
+	wapc.RegisterFunction("apexlang.v1.Parser/validate", parserValidateWrapper(svc, &o))
+	wapc.RegisterFunction("apexlang.v1.Parser/ast", parserASTWrapper(svc, &o))
+	wapc.RegisterFunction("apexlang.v1.Parser/format", parserFormatWrapper(svc, &o))
+	wapc.RegisterFunction("apexlang.v1.Parser/diff", parserDiffWrapper(svc, &o))
 }
 
-func parserParseWrapper(svc model.Parser) wapc.Function {
+func parserParseWrapper(svc model.Parser, o *options) wapc.Function {
 	return func(payload []byte) ([]byte, error) {
-		ctx := context.Background()
-		decoder := msgpack.NewDecoder(payload)
+		ctx := o.context("apexlang.v1.Parser/parse")
 		var inputArgs model.ParserParseArgs
-		inputArgs.Decode(&decoder)
+		if err := model.Decode(payload, &inputArgs); err != nil {
+			return nil, err
+		}
 		response, err := svc.Parse(ctx, inputArgs.Source)
 		if err != nil {
 			return nil, err
@@ -62,3 +108,63 @@ func parserParseWrapper(svc model.Parser) wapc.Function {
 		return msgpack.ToBytes(response)
 	}
 }
+
+func parserValidateWrapper(svc model.ParserService, o *options) wapc.Function {
+	return func(payload []byte) ([]byte, error) {
+		ctx := o.context("apexlang.v1.Parser/validate")
+		var inputArgs model.ParserValidateArgs
+		if err := model.Decode(payload, &inputArgs); err != nil {
+			return nil, err
+		}
+		response, err := svc.Validate(ctx, inputArgs.Source)
+		if err != nil {
+			return nil, err
+		}
+		return msgpack.ToBytes(response)
+	}
+}
+
+func parserASTWrapper(svc model.ParserService, o *options) wapc.Function {
+	return func(payload []byte) ([]byte, error) {
+		ctx := o.context("apexlang.v1.Parser/ast")
+		var inputArgs model.ParserASTArgs
+		if err := model.Decode(payload, &inputArgs); err != nil {
+			return nil, err
+		}
+		response, err := svc.AST(ctx, inputArgs.Source)
+		if err != nil {
+			return nil, err
+		}
+		return msgpack.ToBytes(response)
+	}
+}
+
+func parserFormatWrapper(svc model.ParserService, o *options) wapc.Function {
+	return func(payload []byte) ([]byte, error) {
+		ctx := o.context("apexlang.v1.Parser/format")
+		var inputArgs model.ParserFormatArgs
+		if err := model.Decode(payload, &inputArgs); err != nil {
+			return nil, err
+		}
+		response, err := svc.Format(ctx, inputArgs.Source)
+		if err != nil {
+			return nil, err
+		}
+		return msgpack.ToBytes(response)
+	}
+}
+
+func parserDiffWrapper(svc model.ParserService, o *options) wapc.Function {
+	return func(payload []byte) ([]byte, error) {
+		ctx := o.context("apexlang.v1.Parser/diff")
+		var inputArgs model.ParserDiffArgs
+		if err := model.Decode(payload, &inputArgs); err != nil {
+			return nil, err
+		}
+		response, err := svc.Diff(ctx, &inputArgs.From, &inputArgs.To)
+		if err != nil {
+			return nil, err
+		}
+		return msgpack.ToBytes(response)
+	}
+}
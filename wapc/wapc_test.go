@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wapc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	msgpack "github.com/wapc/tinygo-msgpack"
+
+	"github.com/apexlang/apex-go/model"
+)
+
+// contextParser records the context of Parse and fails like the
+// parser does once it is done.
+type contextParser struct {
+	model.Parser
+	ctx context.Context
+}
+
+func (p *contextParser) Parse(ctx context.Context, source string) (*model.ParserResult, error) {
+	p.ctx = ctx
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &model.ParserResult{}, nil
+}
+
+func TestWithContext(t *testing.T) {
+	payload, err := msgpack.ToBytes(&model.ParserParseArgs{Source: `namespace "test"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var operations []string
+	o := options{}
+	WithContext(func(operation string) context.Context {
+		operations = append(operations, operation)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	})(&o)
+
+	svc := &contextParser{}
+	if _, err := parserParseWrapper(svc, &o)(payload); !errors.Is(err, context.Canceled) {
+		t.Errorf("parse with a canceled context = %v, want %v", err, context.Canceled)
+	}
+	const operation = "apexlang.v1.Parser/parse"
+	if len(operations) != 1 || operations[0] != operation {
+		t.Errorf("contexts created for %v, want [%s]", operations, operation)
+	}
+	if svc.ctx == nil || Operation(svc.ctx) != operation {
+		t.Errorf("service did not receive the context of %s", operation)
+	}
+
+	if _, err := parserParseWrapper(model.NewParser(NewResolver()), &o)(payload); !errors.Is(err, context.Canceled) {
+		t.Errorf("parser with a canceled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDefaultContext(t *testing.T) {
+	ctx := (&options{}).context("apexlang.v1.Parser/validate")
+	if err := ctx.Err(); err != nil {
+		t.Errorf("default context is done: %v", err)
+	}
+	if operation := Operation(ctx); operation != "apexlang.v1.Parser/validate" {
+		t.Errorf("Operation() = %q", operation)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apexlang/apex-go/parser"
+)
+
+// DirResolver returns a resolver that reads imports from the files
+// found by LookupImport.
+func DirResolver(roots ...string) parser.Resolver {
+	return func(location, from string) (string, error) {
+		path, err := LookupImport(location, from, roots...)
+		if err != nil {
+			return "", err
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(body), nil
+	}
+}
+
+// LookupImport returns the path of the file imported from location by
+// the spec named from. Imports are looked up in definition roots, such
+// as the definitions directory of APEX_HOME: an import of
+// "@apexlang/core" is found at "<root>/@apexlang/core", at
+// "<root>/@apexlang/core.apex" or at "<root>/@apexlang/core/index.apex"
+// of the first root containing one of them. Relative imports are
+// resolved against the importing spec instead.
+func LookupImport(location, from string, roots ...string) (string, error) {
+	if strings.HasPrefix(location, "./") || strings.HasPrefix(location, "../") {
+		path := filepath.FromSlash(parser.ImportLocation(location, from))
+		if found, ok := lookupCandidates(path); ok {
+			return found, nil
+		}
+		return "", fmt.Errorf("could not resolve import %q from %q", location, from)
+	}
+
+	for _, root := range roots {
+		path := filepath.Join(root, filepath.FromSlash(location))
+		if found, ok := lookupCandidates(path); ok {
+			return found, nil
+		}
+	}
+	return "", fmt.Errorf("could not find %q in %s", location, strings.Join(roots, ", "))
+}
+
+// lookupCandidates returns the first existing spec for the import path.
+func lookupCandidates(path string) (string, bool) {
+	for _, candidate := range []string{
+		path,
+		path + Extension,
+		filepath.Join(path, "index"+Extension),
+	} {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
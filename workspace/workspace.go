@@ -0,0 +1,340 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workspace parses and validates a set of Apex specs together,
+// resolving imports between them in memory.
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Extension is the file extension of Apex specs.
+const Extension = ".apex"
+
+// Options configures how a workspace is loaded.
+type Options struct {
+	// Resolver resolves imports that do not refer to a file in the
+	// workspace. Such imports fail if it is nil.
+	Resolver parser.Resolver
+	// Rules are the validation rules to apply, rules.Rules if nil.
+	Rules []rules.ValidationRule
+	// ParseOnly skips validation and conversion.
+	ParseOnly bool
+	// ImportCache, if set, reuses the documents of unchanged imports
+	// when specs are loaded again.
+	ImportCache *parser.ImportCache
+	// ParseOptions are the options specs are parsed with, such as
+	// limits. Their resolvers and import cache are replaced by the
+	// ones above.
+	ParseOptions parser.ParseOptions
+}
+
+// Workspace is a set of specs loaded together.
+type Workspace struct {
+	Files []*File
+
+	options     Options
+	byPath      map[string]*File
+	byNamespace map[string][]*File
+}
+
+// File is a spec in a workspace along with its diagnostics.
+type File struct {
+	// Path is the path the file was loaded from.
+	Path string
+	// Source is the contents of the file.
+	Source *source.Source
+	// NamespaceName is the name declared by the namespace definition,
+	// if any.
+	NamespaceName string
+	namespaceDef  *ast.NamespaceDefinition
+	// Document is the parsed spec including its imports. It is nil if
+	// the spec could not be parsed.
+	Document *ast.Document
+	// Namespace is the converted spec. It is nil if the spec has errors.
+	Namespace *model.Namespace
+	// Errors are the diagnostics reported for the spec.
+	Errors []error
+}
+
+// Load loads the specs matched by patterns into a workspace and
+// validates them. A pattern is a file, a directory, which is searched
+// recursively for files ending in Extension, or a glob. An error is
+// returned if a file cannot be read, diagnostics are reported per file.
+func Load(patterns []string, options Options) (*Workspace, error) {
+	return LoadContext(context.Background(), patterns, options)
+}
+
+// LoadContext is like Load but stops with ctx.Err() once ctx is done.
+func LoadContext(ctx context.Context, patterns []string, options Options) (*Workspace, error) {
+	paths, err := Find(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*source.Source, len(paths))
+	for i, path := range paths {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources[i] = source.NewSource(path, body)
+	}
+
+	return NewContext(ctx, sources, options)
+}
+
+// New creates a workspace from sources named after their paths and
+// validates them.
+func New(sources []*source.Source, options Options) *Workspace {
+	w, _ := NewContext(context.Background(), sources, options)
+	return w
+}
+
+// NewContext is like New but stops with ctx.Err() once ctx is done.
+// The context is passed to the parser, the validation rules and the
+// conversion of each spec.
+func NewContext(ctx context.Context, sources []*source.Source, options Options) (*Workspace, error) {
+	if options.Rules == nil {
+		options.Rules = rules.Rules
+	}
+	w := Workspace{
+		options:     options,
+		byPath:      make(map[string]*File, len(sources)),
+		byNamespace: make(map[string][]*File, len(sources)),
+	}
+
+	for _, src := range sources {
+		file := File{
+			Path:   src.Name,
+			Source: src,
+		}
+		// The errors of a spec that does not parse are reported by
+		// check.
+		file.namespaceDef, _ = parser.ParseNamespaceContext(ctx, parser.ParseParams{
+			Source:  src,
+			Options: options.ParseOptions,
+		})
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if file.namespaceDef != nil {
+			file.NamespaceName = file.namespaceDef.Name.Value
+		}
+		w.Files = append(w.Files, &file)
+		w.byPath[cleanPath(src.Name)] = &file
+		if file.NamespaceName != "" {
+			w.byNamespace[file.NamespaceName] = append(w.byNamespace[file.NamespaceName], &file)
+		}
+	}
+	sort.SliceStable(w.Files, func(i, j int) bool {
+		return w.Files[i].Path < w.Files[j].Path
+	})
+
+	for _, file := range w.Files {
+		w.check(ctx, file)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &w, nil
+}
+
+// HasErrors returns true if any file in the workspace has diagnostics.
+func (w *Workspace) HasErrors() bool {
+	for _, file := range w.Files {
+		if len(file.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the diagnostics of all files in path order.
+func (w *Workspace) Errors() []error {
+	var errs []error
+	for _, file := range w.Files {
+		errs = append(errs, file.Errors...)
+	}
+	return errs
+}
+
+// Namespaces returns the converted namespace of each file without errors.
+func (w *Workspace) Namespaces() []*model.Namespace {
+	var namespaces []*model.Namespace
+	for _, file := range w.Files {
+		if file.Namespace != nil {
+			namespaces = append(namespaces, file.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// File returns the file loaded from path.
+func (w *Workspace) File(path string) (*File, bool) {
+	file, ok := w.byPath[cleanPath(path)]
+	return file, ok
+}
+
+// Resolve resolves an import from the spec named from. Imports are
+// matched against the namespaces of the workspace files first, then
+// against their paths, relative to from unless absolute, with or
+// without Extension. Anything else is passed to the configured
+// resolver.
+func (w *Workspace) Resolve(location, from string) (string, error) {
+	if files := w.byNamespace[location]; len(files) == 1 {
+		return string(files[0].Source.Body), nil
+	}
+
+	path := filepath.FromSlash(location)
+	if !filepath.IsAbs(path) && from != "" {
+		path = filepath.Join(filepath.Dir(filepath.FromSlash(from)), path)
+	}
+	for _, candidate := range []string{path, path + Extension} {
+		if file, ok := w.byPath[cleanPath(candidate)]; ok {
+			return string(file.Source.Body), nil
+		}
+	}
+
+	if w.options.Resolver != nil {
+		return w.options.Resolver(location, from)
+	}
+	return "", fmt.Errorf("could not resolve import %q", location)
+}
+
+func (w *Workspace) check(ctx context.Context, file *File) {
+	if others := w.byNamespace[file.NamespaceName]; len(others) > 1 {
+		var paths []string
+		for _, other := range others {
+			if other != file {
+				paths = append(paths, other.Path)
+			}
+		}
+		sort.Strings(paths)
+		file.Errors = append(file.Errors, errors.NewError(
+			fmt.Sprintf("namespace %q is also defined in %s",
+				file.NamespaceName, strings.Join(paths, ", ")),
+			[]ast.Node{file.namespaceDef.Name},
+			"",
+			file.Source,
+			nil,
+			nil,
+		))
+	}
+
+	options := w.options.ParseOptions
+	options.Resolver = w.Resolve
+	options.ContextResolver = nil
+	options.ImportCache = w.options.ImportCache
+	doc, err := parser.ParseContext(ctx, parser.ParseParams{
+		Source:  file.Source,
+		Options: options,
+	})
+	if err != nil {
+		file.Errors = append(file.Errors, err)
+		return
+	}
+	file.Document = doc
+	if w.options.ParseOnly {
+		return
+	}
+
+	if errs := rules.ValidateContext(ctx, doc, w.options.Rules...); len(errs) > 0 {
+		file.Errors = append(file.Errors, errs...)
+		return
+	}
+	if len(file.Errors) > 0 {
+		return
+	}
+
+	ns, errs := model.ConvertContext(ctx, doc)
+	if len(errs) > 0 {
+		file.Errors = append(file.Errors, errs...)
+		return
+	}
+	file.Namespace = ns
+}
+
+// Find returns the sorted, deduplicated paths of the specs matched by
+// patterns. See Load for the supported patterns.
+func Find(patterns ...string) ([]string, error) {
+	seen := map[string]struct{}{}
+	var paths []string
+	add := func(path string) {
+		if _, ok := seen[path]; !ok {
+			seen[path] = struct{}{}
+			paths = append(paths, path)
+		}
+	}
+
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(pattern); err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("%s: no specs match the pattern", pattern)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				add(filepath.Clean(match))
+				continue
+			}
+			err = filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() && strings.HasSuffix(path, Extension) {
+					add(filepath.Clean(path))
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func cleanPath(path string) string {
+	return filepath.Clean(filepath.FromSlash(path))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace_test
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+	"github.com/apexlang/apex-go/workspace"
+)
+
+func TestImports(t *testing.T) {
+	w := workspace.New([]*source.Source{
+		source.NewSource("specs/a.apex", []byte(`namespace "a"
+import { B } from "./b.apex"
+type A { b: B }
+`)),
+		source.NewSource("specs/b.apex", []byte(`namespace "b"
+type B { name: string }
+`)),
+	}, workspace.Options{})
+	if errs := w.Errors(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if got := len(w.Namespaces()); got != 2 {
+		t.Errorf("got %d namespaces, want 2", got)
+	}
+}
+
+func TestImportCycle(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.apex": "namespace \"a\"\nimport * from \"./b.apex\"\n",
+		"b.apex": "namespace \"b\"\nimport * from \"./a.apex\"\n",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w, err := workspace.Load([]string{dir}, workspace.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := filepath.ToSlash(filepath.Join(dir, "a.apex"))
+	b := filepath.ToSlash(filepath.Join(dir, "b.apex"))
+	tests := []struct {
+		file    string
+		message string
+		source  string
+	}{
+		{a, "import cycle: " + a + " -> " + b + " -> " + a, b},
+		{b, "import cycle: " + b + " -> " + a + " -> " + b, a},
+	}
+	for _, tt := range tests {
+		file, ok := w.File(tt.file)
+		if !ok {
+			t.Fatalf("%s not loaded", tt.file)
+		}
+		if len(file.Errors) != 1 {
+			t.Fatalf("%s: got errors %v, want one", tt.file, file.Errors)
+		}
+		e, ok := file.Errors[0].(*errors.Error)
+		if !ok {
+			t.Fatalf("%s: got %T, want *errors.Error", tt.file, file.Errors[0])
+		}
+		if e.Message != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.file, e.Message, tt.message)
+		}
+		if e.Source == nil || e.Source.Name != tt.source {
+			t.Errorf("%s: error is not reported in %s", tt.file, tt.source)
+		}
+		if len(e.Locations) != 1 || e.Locations[0].Line != 2 || e.Locations[0].Column != 15 {
+			t.Errorf("%s: got locations %v, want 2:15", tt.file, e.Locations)
+		}
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	sources := []*source.Source{
+		source.NewSource("a.apex", []byte("namespace \"a\"\nimport { B } from \"b\"\ntype A { b: B }\n")),
+		source.NewSource("b.apex", []byte("namespace \"b\"\ntype B { name: string }\n")),
+	}
+
+	// The workspace resolves imports itself rather than with the
+	// resolver of the parse options.
+	w := workspace.New(sources, workspace.Options{
+		ParseOptions: parser.ParseOptions{
+			MaxImports: 1,
+			Resolver: func(location, from string) (string, error) {
+				return "", stderrors.New("not resolved by the workspace")
+			},
+		},
+	})
+	if errs := w.Errors(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	w = workspace.New(sources, workspace.Options{
+		ParseOptions: parser.ParseOptions{MaxTokens: 10},
+	})
+	file, _ := w.File("a.apex")
+	if len(file.Errors) != 1 || !stderrors.Is(file.Errors[0], errors.ErrLimitExceeded) {
+		t.Errorf("got errors %v, want a limit error", file.Errors)
+	}
+	if file.NamespaceName != "a" {
+		t.Errorf("got namespace %q, want a", file.NamespaceName)
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := workspace.NewContext(ctx, []*source.Source{
+		source.NewSource("a.apex", []byte(`namespace "a"`)),
+	}, workspace.Options{})
+	if !stderrors.Is(err, context.Canceled) {
+		t.Errorf("NewContext() with a canceled context = %v, want %v", err, context.Canceled)
+	}
+}